@@ -3,18 +3,31 @@
 package echo_binder
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"gopkg.in/yaml.v3"
 )
 
-// A replacement for the echo.DefaultBinder that binds the Path, Query, Header, Body and Form params
+// A replacement for the echo.DefaultBinder that binds the Path, Query, Header, Cookie, Body and Form params
 // into nested structures that passed into the binder, and finally valiate the structure with the go-playground/validator
 // package. For more information about the validator check: https://pkg.go.dev/github.com/go-playground/validator
 //
@@ -63,408 +76,3052 @@ import (
 // From the header, the User-Agent field will be bound to the UserAgent field of the struct.
 type Binder struct {
 	validator                    *validator.Validate
+	ownsValidator                bool
 	callEchoDefaultBinderOnError bool
 	defaultBinder                *echo.DefaultBinder
 	ignoreNullStringOnHeader     bool
+	defaultTimeFormat            string
+	generators                   map[string]func() (string, error)
+	defaultFuncs                 map[string]func(echo.Context) (string, error)
+	writeGeneratedHeaders        bool
+	lowercaseHeaderLookup        bool
+	signatureSecret              []byte
+	headerDecryptor              func(field, value string) (string, error)
+	bodyDecoders                 map[string]func([]byte, interface{}) error
+	disableBodySentFields        bool
+	strictQuery                  bool
+	strictForm                   bool
+	strictBody                   bool
+	recordReport                 bool
+	inferScalarTypes             bool
+	maxQueryParams               int
+	converters                   map[reflect.Type]func(string) (interface{}, error)
+	tagName                      string
+	dottedForm                   bool
+	dottedQuery                  bool
+	sectionPrefixes              map[string]string
+	jsonTagFallback              bool
+	charsetDecoder               func(charset string, r io.Reader) (io.Reader, error)
+	translator                   ut.Translator
+	errorFormatter               func(validator.ValidationErrors) interface{}
+	maxFileSize                  int64
+	queryMethods                 map[string]bool
+	categoryStatuses             map[error]int
+	boolValues                   map[string]bool
+	unvalidatedSections          map[string]bool
+
+	// The report of the current binding, only set on the copy of the binder that Bind works on
+	report *BindReport
 }
 
-func New() *Binder {
-	return &Binder{
-		validator:                    validator.New(),
+// Configures the binder that is created by New.
+type Option func(binder *Binder)
+
+// Validates the bound structures with validate instead of a new validator, so the custom validations, translations
+// and the field-name function that were registered on it at startup are used by every request. The validator is used
+// as-is, the binder doesn't register its own field-name function on it.
+func WithValidator(validate *validator.Validate) Option {
+	return func(binder *Binder) {
+		binder.validator = validate
+		binder.ownsValidator = false
+	}
+}
+
+// Disables the validation of the bound structures, Bind only binds the values and never calls the validator.
+func WithoutValidation() Option {
+	return func(binder *Binder) {
+		binder.validator = nil
+	}
+}
+
+func New(options ...Option) *Binder {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(getValidationFieldName(TagIdentifier))
+
+	binder := &Binder{
+		validator:                    validate,
+		ownsValidator:                true,
 		callEchoDefaultBinderOnError: false,
 		defaultBinder:                new(echo.DefaultBinder),
 		ignoreNullStringOnHeader:     false,
+		defaultTimeFormat:            time.RFC3339,
+		generators:                   map[string]func() (string, error){uuidGenerator: generateUUID},
+		defaultFuncs:                 map[string]func(echo.Context) (string, error){},
+		writeGeneratedHeaders:        false,
+		lowercaseHeaderLookup:        false,
+		bodyDecoders:                 map[string]func([]byte, interface{}) error{},
+		converters:                   map[reflect.Type]func(string) (interface{}, error){},
+		tagName:                      TagIdentifier,
+		sectionPrefixes:              map[string]string{},
+		unvalidatedSections:          map[string]bool{},
+		categoryStatuses:             map[error]int{},
+		boolValues:                   getBoolValues(defaultTrueValues, defaultFalseValues),
+	}
+
+	for _, option := range options {
+		option(binder)
+	}
+
+	return binder
+}
+
+func (binder *Binder) CallEchoDefaultBinderOnError(value bool) {
+	binder.callEchoDefaultBinderOnError = value
+}
+
+func (binder *Binder) IgnoreNullStringOnHeader(value bool) {
+	binder.ignoreNullStringOnHeader = value
+}
+
+// Returns the function that names the fields for the validator by the name the client sent them by:
+// the binder tag (tagName), then the json tag (for body fields) and finally the field name itself.
+func getValidationFieldName(tagName string) func(reflect.StructField) string {
+	return func(field reflect.StructField) string {
+		for _, tag := range []string{tagName, jsonTag} {
+			if name, _ := parseTag(field.Tag.Get(tag)); name != "" && name != "-" {
+				return name
+			}
+		}
+
+		return field.Name
+	}
+}
+
+// Sets the struct tag the fields are bound by instead of `binder` (for example `param`, when migrating from another
+// binder). The syntax of the tag stays the same, and fields without it are still bound by their name.
+func (binder *Binder) SetTagName(name string) {
+	if name == "" {
+		name = TagIdentifier
+	}
+
+	binder.tagName = name
+	if binder.validator != nil && binder.ownsValidator {
+		// An injected validator reports the field names by the function that was registered on it
+		binder.validator.RegisterTagNameFunc(getValidationFieldName(name))
+	}
+}
+
+// Sets the layout that is used to parse time.Time fields that don't declare a `time_format` tag.
+// The layout is in the format of time.Parse, and defaults to time.RFC3339.
+func (binder *Binder) SetDefaultTimeFormat(layout string) {
+	binder.defaultTimeFormat = layout
+}
+
+// Looks up the headers by their lowercased names instead of their canonical form, for requests whose header map
+// wasn't normalized (for example behind proxies that forward HTTP/2 lowercase headers as is).
+func (binder *Binder) SetLowercaseHeaderLookup(value bool) {
+	binder.lowercaseHeaderLookup = value
+}
+
+// Sets the secret that is used to verify the body signatures of header fields tagged with `binder:"X-Signature,hmac"`.
+// The signature is the hex encoded HMAC-SHA256 of the raw body, optionally prefixed by `sha256=`.
+func (binder *Binder) SetSignatureSecret(secret []byte) {
+	binder.signatureSecret = secret
+}
+
+// Sets the function that decrypts the values of the header fields tagged with `binder:"X-Token,decrypt"`, it gets
+// the name of the header and its encrypted value and returns the decrypted one, which is then bound as if it was sent.
+// A decryption failure fails the binding with 400 Bad Request.
+func (binder *Binder) SetHeaderDecryptor(decryptor func(field, value string) (string, error)) {
+	binder.headerDecryptor = decryptor
+}
+
+// Registers a decoder for bodies of the media type (for example `application/x-protobuf`), which takes precedence
+// over the built in JSON and XML decoding. The decoder gets the raw body and a pointer to the Body field, and the
+// `BodySentFields` are not tracked for the bodies it decodes.
+func (binder *Binder) RegisterBodyDecoder(mediaType string, decoder func(body []byte, i interface{}) error) {
+	binder.bodyDecoders[getMediaType(mediaType)] = decoder
+}
+
+// Disables the tracking of the fields that were sent in the body, which decodes the body a second time, even for
+// request structs that declare the `BodySentFields` field. The field is then left untouched, so its FieldExists
+// reports all of the fields as absent.
+func (binder *Binder) DisableBodySentFields(value bool) {
+	binder.disableBodySentFields = value
+}
+
+// Registers a converter for values of the (non struct) type, such as uuid.UUID, which is used instead of the built in
+// parsing for fields of the type, pointers to it and slices of it. The converter must return a value of the type,
+// or for interface types any value that implements the interface.
+func (binder *Binder) RegisterConverter(valueType reflect.Type, converter func(value string) (interface{}, error)) {
+	binder.converters[valueType] = converter
+}
+
+// Registers a generator that can be used by header fields tagged with `binder:"X-Request-Id,generate=name"`,
+// when the header is absent the generator is called and its value is bound instead.
+// The `uuid` generator is registered by default.
+func (binder *Binder) RegisterGenerator(name string, generator func() (string, error)) {
+	binder.generators[name] = generator
+}
+
+// Registers a function that computes the default of a param that wasn't sent, for defaults that aren't static (such
+// as `since` defaulting to 24 hours ago). The field is the section and the identifier of the param (`Query.since`),
+// and the returned value is parsed just like a sent value. The function takes precedence over the `default` tag.
+func (binder *Binder) SetDefaultFunc(field string, fn func(c echo.Context) (string, error)) {
+	binder.defaultFuncs[field] = fn
+}
+
+// Writes the generated header values back to the response headers, so the client can see them as well.
+func (binder *Binder) WriteGeneratedHeaders(value bool) {
+	binder.writeGeneratedHeaders = value
+}
+
+// Fails the binding of query params that aren't bound into any of the fields of the `Query` section.
+func (binder *Binder) StrictQuery(value bool) {
+	binder.strictQuery = value
+}
+
+// Fails the binding of form params that aren't bound into any of the fields of the `Form` section.
+func (binder *Binder) StrictForm(value bool) {
+	binder.strictForm = value
+}
+
+// Fails the binding of JSON bodies with fields that don't exist in the `Body` section.
+func (binder *Binder) StrictBody(value bool) {
+	binder.strictBody = value
+}
+
+// Toggles the strict mode of all of the sections at once, path params are always strict.
+func (binder *Binder) StrictAll(value bool) {
+	binder.StrictQuery(value)
+	binder.StrictForm(value)
+	binder.StrictBody(value)
+}
+
+// Binds the fields of nested structures in the `Form` section from dotted keys (`address.city` into `Address.City`),
+// the path is made of the tags of the structures (or their names), while embedded structures are still promoted.
+func (binder *Binder) DottedFormKeys(value bool) {
+	binder.dottedForm = value
+}
+
+// Binds the fields of nested structures in the `Query` section from dotted keys (`?address.city=NYC` into
+// `Address.City`), just like DottedFormKeys does for the `Form` section.
+func (binder *Binder) DottedQueryKeys(value bool) {
+	binder.dottedQuery = value
+}
+
+// Returns whether the fields of the nested structures of the section are identified by their dotted path
+func (binder *Binder) dottedKeys(location string) bool {
+	return (location == formField && binder.dottedForm) || (location == queryField && binder.dottedQuery)
+}
+
+// Identifies the fields that don't have the binder tag by their `json` tag (the name before the options) in all of
+// the sections, so structs that already carry json tags don't have to repeat them. The binder tag still takes
+// precedence, and fields without both of the tags (or with `json:"-"`) are identified by their name.
+func (binder *Binder) JSONTagFallback(value bool) {
+	binder.jsonTagFallback = value
+}
+
+// Sets the decoder that transcodes bodies whose content type declares another charset than UTF-8
+// (`application/json; charset=iso-8859-1`) to UTF-8 before they are decoded, for legacy clients. The decoder gets the
+// lowercased charset and the raw body, and returns a reader of the UTF-8 body, or an error (which fails the binding
+// with 415 Unsupported Media Type) for charsets it doesn't know. It's nil by default, so the bodies are decoded as
+// UTF-8 whatever their charset is.
+func (binder *Binder) SetCharsetDecoder(decoder func(charset string, r io.Reader) (io.Reader, error)) {
+	binder.charsetDecoder = decoder
+}
+
+// Prepends the prefix to the identifiers of all of the fields of the section (such as `Query`) when matching the
+// params, so with the `v2_` prefix the `name` field is bound from `v2_name`. This lets the same struct serve prefixed
+// and unprefixed variants of an API through different binders. The keys of indexed and nested-query structures
+// (`users[0].name`) are not prefixed, and an empty prefix removes it.
+func (binder *Binder) SetSectionPrefix(section, prefix string) {
+	if prefix == "" {
+		delete(binder.sectionPrefixes, section)
+		return
+	}
+
+	binder.sectionPrefixes[section] = prefix
+}
+
+// Turns the validation of the fields of the section (such as `Query`) on or off, for sections that are validated
+// elsewhere. The other sections are still validated by Bind, and all of the sections are validated by default.
+func (binder *Binder) SetSectionValidation(section string, enabled bool) {
+	if enabled {
+		delete(binder.unvalidatedSections, section)
+		return
+	}
+
+	binder.unvalidatedSections[section] = true
+}
+
+// Returns the key of the schema of the structure type at location, prefix is the prefix of its identifiers
+func (binder *Binder) getSchemaKey(location string, structType reflect.Type, prefix string) schemaKey {
+	return schemaKey{
+		location:     location,
+		tagName:      binder.tagName,
+		structType:   structType,
+		dotted:       binder.dottedKeys(location),
+		prefix:       prefix,
+		jsonFallback: binder.jsonTagFallback,
+	}
+}
+
+// Translates the validation errors with the translator, which fail the binding as *TranslatedValidationErrors.
+// The translations of the validator are registered by the register functions, for example
+// `binder.SetTranslator(trans, en_translations.RegisterDefaultTranslations)`, and fields without a registered
+// translation fall back to the message of the validator. A nil translator turns the translation off.
+func (binder *Binder) SetTranslator(trans ut.Translator, register ...func(*validator.Validate, ut.Translator) error) error {
+	binder.translator = trans
+	if trans == nil || binder.validator == nil {
+		return nil
+	}
+
+	for _, registerTranslations := range register {
+		if err := registerTranslations(binder.validator, trans); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Registers a custom validation for the tag on the validator of the binder, see validator.Validate.RegisterValidation.
+// Fails when the validation is disabled, since there is no validator to register it on.
+func (binder *Binder) RegisterValidation(tag string, fn validator.Func) error {
+	if binder.validator == nil {
+		return errorValidationDisabled
+	}
+
+	return binder.validator.RegisterValidation(tag, fn)
+}
+
+// Sets the function that formats the validation errors into the message of the *echo.HTTPError the binding fails
+// with, for example a map of every field to its message, instead of the flat string of the errors. The error is
+// still available as the internal error of the HTTPError. A nil formatter restores the flat string.
+func (binder *Binder) SetErrorFormatter(formatter func(validator.ValidationErrors) interface{}) {
+	binder.errorFormatter = formatter
+}
+
+// Sets the status code of the errors of the category (such as ErrMissingParam), instead of 400 Bad Request.
+// A zero status restores the default.
+func (binder *Binder) SetCategoryStatus(category error, status int) {
+	if status == 0 {
+		delete(binder.categoryStatuses, category)
+		return
+	}
+
+	binder.categoryStatuses[category] = status
+}
+
+// Sets the status code of the validation errors, for example 422 Unprocessable Entity instead of 400 Bad Request
+func (binder *Binder) SetStatusForValidation(status int) {
+	binder.SetCategoryStatus(ErrValidation, status)
+}
+
+// Returns the HTTP error of a failed binding, it has the status code of the category of the error if one was set
+// and is a Bad Request otherwise (errors that are already HTTP errors keep their status)
+func (binder *Binder) categoryError(err error) *echo.HTTPError {
+	httpError := badRequestError(err)
+
+	bindError := &BindError{}
+	if errors.As(httpError.Internal, &bindError) {
+		if status, ok := binder.categoryStatuses[bindError.Category]; ok {
+			httpError.Code = status
+		}
+	}
+
+	return httpError
+}
+
+// Sets the values that are accepted for booleans instead of the default ones (`true`/`false`, `1`/`0`, `on`/`off`,
+// `yes`/`no`, `y`/`n` and `enabled`/`disabled`). The values are matched case insensitively with the whitespace
+// around them trimmed, an empty value is always false and any other value fails the binding.
+func (binder *Binder) SetBoolValues(trueValues, falseValues []string) {
+	binder.boolValues = getBoolValues(trueValues, falseValues)
+}
+
+func getBoolValues(trueValues, falseValues []string) map[string]bool {
+	values := make(map[string]bool, len(trueValues)+len(falseValues))
+	for _, value := range trueValues {
+		values[strings.ToLower(strings.TrimSpace(value))] = true
+	}
+
+	for _, value := range falseValues {
+		values[strings.ToLower(strings.TrimSpace(value))] = false
+	}
+
+	return values
+}
+
+// Sets the maximum size in bytes of a file that is read into a []byte field of the `File` section, larger files fail
+// the binding with 413 Request Entity Too Large. Zero (the default) doesn't limit the size.
+func (binder *Binder) SetMaxFileSize(size int64) {
+	binder.maxFileSize = size
+}
+
+// Restricts the binding of the `Query` section to requests of the methods, requests of other methods fail the binding.
+// By default the query is bound for all of the methods (for example `?dry_run=true` on a PATCH), and calling it
+// without methods lifts the restriction.
+func (binder *Binder) SetQueryMethods(methods ...string) {
+	if len(methods) == 0 {
+		binder.queryMethods = nil
+		return
+	}
+
+	binder.queryMethods = make(map[string]bool, len(methods))
+	for _, method := range methods {
+		binder.queryMethods[strings.ToUpper(method)] = true
+	}
+}
+
+// Binds the values of empty interface fields (`interface{}`/`any`) as the scalar type they look like (int64, float64
+// or bool) instead of always as a string.
+func (binder *Binder) InferScalarTypes(value bool) {
+	binder.inferScalarTypes = value
+}
+
+// Sets the maximum number of distinct query params a request may carry, requests with more params are rejected before
+// anything is bound. Zero (the default) means unlimited.
+func (binder *Binder) SetMaxQueryParams(max int) {
+	binder.maxQueryParams = max
+}
+
+// Records a report of every binding into the context, which can be retrieved with ReportFromContext.
+func (binder *Binder) RecordReport(value bool) {
+	binder.recordReport = value
+}
+
+// Returns the section fields of the request structure by their index, sections of embedded structs (such as a shared
+// `Paging` struct with a `Query` section) are promoted like Go promotes their fields, so shallower sections hide them.
+func getSectionFields(structType reflect.Type) []reflect.StructField {
+	sections := []reflect.StructField{}
+
+	for _, field := range reflect.VisibleFields(structType) {
+		if _, ok := fieldHandlers[field.Name]; ok && !isInsideSection(structType, field.Index) {
+			sections = append(sections, field)
+		}
+	}
+
+	return sections
+}
+
+// Returns whether the field at index is promoted from a section that is an embedded struct (`struct { Query }`),
+// the fields of a section are never sections themselves
+func isInsideSection(structType reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if _, ok := fieldHandlers[structType.FieldByIndex(index[:i]).Name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validates i, whose type is a pointer to structType, except for the sections whose validation is turned off
+func (binder *Binder) validate(i interface{}, structType reflect.Type) error {
+	if len(binder.unvalidatedSections) == 0 {
+		return binder.validator.Struct(i)
+	}
+
+	excluded := []string{}
+	for _, section := range getSectionFields(structType) {
+		if binder.unvalidatedSections[section.Name] {
+			excluded = append(excluded, getFieldNamespace(structType, section.Index))
+		}
+	}
+
+	if len(excluded) == 0 {
+		return binder.validator.Struct(i)
+	}
+
+	return binder.validator.StructExcept(i, excluded...)
+}
+
+// Returns the path of the Go field names leading to the field at index (`Embedded.Query`), which is how the
+// validator refers to the fields of the struct
+func getFieldNamespace(structType reflect.Type, index []int) string {
+	names := make([]string, len(index))
+	for i := range index {
+		names[i] = structType.FieldByIndex(index[:i+1]).Name
+	}
+
+	return strings.Join(names, ".")
+}
+
+// Returns the field at index of structValue, allocating the nil embedded pointers that lead to it.
+// ok is false when one of them can't be allocated.
+func getSectionValue(structValue reflect.Value, index []int) (reflect.Value, bool) {
+	value := structValue.Field(index[0])
+
+	for _, i := range index[1:] {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !value.CanSet() {
+					return reflect.Value{}, false
+				}
+
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+
+			value = value.Elem()
+		}
+
+		value = value.Field(i)
+	}
+
+	return value, true
+}
+
+// A section (such as the `Query` struct) that implements SectionBinder binds itself from the request, instead of
+// having its fields bound by the binder. The other sections and the validation are not affected.
+type SectionBinder interface {
+	BindSection(c echo.Context) error
+}
+
+var sectionBinderType = reflect.TypeOf((*SectionBinder)(nil)).Elem()
+
+// Returns the SectionBinder of the section, pointer sections (the body) are allocated when they implement it
+func getSectionBinder(structField reflect.Value) (SectionBinder, bool) {
+	if structField.Kind() == reflect.Ptr {
+		if !structField.Type().Implements(sectionBinderType) {
+			return nil, false
+		}
+
+		if structField.IsNil() {
+			if !structField.CanSet() {
+				return nil, false
+			}
+
+			structField.Set(reflect.New(structField.Type().Elem()))
+		}
+
+		return structField.Interface().(SectionBinder), true
+	}
+
+	if !structField.CanAddr() || !reflect.PtrTo(structField.Type()).Implements(sectionBinderType) {
+		return nil, false
+	}
+
+	return structField.Addr().Interface().(SectionBinder), true
+}
+
+func (binder Binder) Bind(i interface{}, c echo.Context) error {
+	if binder.recordReport {
+		// Bind works on a copy of the binder, so the report is attached to the current binding only
+		binder.report = newBindReport()
+		c.Set(reportContextKey, binder.report)
+
+		start := time.Now()
+		defer func() { binder.report.Duration = time.Since(start) }()
+	}
+
+	structType := reflect.TypeOf(i)
+
+	// Make sure that we get a structure to bind
+	if structType == nil || structType.Kind() != reflect.Ptr || reflect.ValueOf(i).IsNil() {
+		return badRequestError(errorInvalidType)
+	}
+
+	// Get the actual element instead of the pointer
+	structType = structType.Elem()
+
+	// Check that the data is actually a struct
+	if structType.Kind() != reflect.Struct {
+		if binder.callEchoDefaultBinderOnError {
+			return binder.defaultBinder.Bind(i, c)
+		}
+
+		return badRequestError(errorInvalidType)
+	}
+
+	structValue := reflect.ValueOf(i).Elem()
+
+	calledHandler := false
+
+	// Iterate over all the sections of the structure (including the ones of embedded structs) and bind them
+	for _, typeField := range getSectionFields(structType) {
+		handler := fieldHandlers[typeField.Name]
+
+		kind := typeField.Type.Kind()
+
+		// If the kind is a pointer get the actual kind
+		if kind == reflect.Ptr {
+			kind = typeField.Type.Elem().Kind()
+		}
+
+		// If the field is not a structure, return an error for that field
+		// Only if the field is not a body (or a map of all of the path params)
+		if kind != reflect.Struct && typeField.Name != bodyField && !isPathMapSection(typeField) {
+			if binder.callEchoDefaultBinderOnError {
+				return binder.defaultBinder.Bind(i, c)
+			}
+
+			return badRequestError(getInvalidTypeAtLocationError(typeField.Name, structTypeString))
+		}
+
+		// Get the structField of the field, allocating the embedded pointers leading to it
+		structField, ok := getSectionValue(structValue, typeField.Index)
+		if !ok {
+			return badRequestError(getNotSettableParamAtLocationError(structType.Name(), typeField.Name))
+		}
+
+		// Sections declared as pointers are allocated and dereferenced, except for the body which is decoded as is
+		if typeField.Type.Kind() == reflect.Ptr && typeField.Name != bodyField {
+			if !structField.CanSet() {
+				return badRequestError(getNotSettableParamAtLocationError(structType.Name(), typeField.Name))
+			}
+
+			if structField.IsNil() {
+				structField.Set(reflect.New(typeField.Type.Elem()))
+			}
+
+			structField = structField.Elem()
+		}
+
+		calledHandler = true
+		start := time.Now()
+
+		var err error
+		if sectionBinder, ok := getSectionBinder(structField); ok {
+			// The section binds itself instead of being walked
+			err = sectionBinder.BindSection(c)
+		} else {
+			err = handler(&binder, c, structType, &structValue, &structField)
+		}
+
+		if binder.report != nil {
+			binder.report.SectionDurations[typeField.Name] = time.Since(start)
+		}
+
+		if err != nil {
+			return binder.categoryError(err)
+		}
+	}
+
+	if !calledHandler && binder.callEchoDefaultBinderOnError {
+		return binder.defaultBinder.Bind(i, c)
+	}
+
+	if binder.validator != nil {
+		if err := binder.validate(i, structType); err != nil {
+			validationErrors := validator.ValidationErrors{}
+			if !errors.As(err, &validationErrors) {
+				return badRequestError(err)
+			}
+
+			if binder.translator != nil {
+				err = &TranslatedValidationErrors{Errors: validationErrors, Translations: validationErrors.Translate(binder.translator)}
+			}
+
+			err = &BindError{Category: ErrValidation, Err: err}
+			if binder.errorFormatter != nil {
+				return binder.categoryError(echo.NewHTTPError(http.StatusBadRequest, binder.errorFormatter(validationErrors)).SetInternal(err))
+			}
+
+			return binder.categoryError(err)
+		}
+	}
+
+	return nil
+}
+
+var (
+	fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+	multipartType  = reflect.TypeOf((*multipart.Form)(nil))
+	fileBytesType  = reflect.TypeOf([]byte(nil))
+	byteRangeType  = reflect.TypeOf(ByteRange{})
+	readerType     = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+
+	// The formats of the HTML `datetime-local` (with and without seconds) and `date` inputs
+	htmlTimeLayouts = []string{"2006-01-02T15:04", "2006-01-02T15:04:05", "2006-01-02"}
+
+	// The values that are accepted for booleans by default, HTML checkboxes are sent as `on`
+	defaultTrueValues  = []string{"1", "t", "true", "on", "yes", "y", "enabled"}
+	defaultFalseValues = []string{"0", "f", "false", "off", "no", "n", "disabled"}
+)
+
+// Allocates a new value of type t, binds the request into it and returns the pointer to it.
+// This is useful when the type to bind is only known at runtime (for example from the route definition).
+func (binder Binder) BindType(t reflect.Type, c echo.Context) (interface{}, error) {
+	if t == nil {
+		return nil, badRequestError(errorInvalidType)
+	}
+
+	i := reflect.New(t).Interface()
+	if err := binder.Bind(i, c); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// Binds the request into i just like Bind, and on error writes the error into the response as JSON and returns
+// false, so handlers can just return: `if !binder.BindAndRespond(&req, c) { return nil }`. The body is an
+// ErrorResponse with the message of the error and the messages of the fields that failed the validation (translated
+// when there is a translator), unless there is an error formatter, whose value is written as is.
+func (binder Binder) BindAndRespond(i interface{}, c echo.Context) bool {
+	err := binder.Bind(i, c)
+	if err == nil {
+		return true
+	}
+
+	httpError := badRequestError(err)
+	if binder.errorFormatter != nil {
+		if _, ok := httpError.Message.(string); !ok {
+			_ = c.JSON(httpError.Code, httpError.Message)
+			return false
+		}
+	}
+
+	_ = c.JSON(httpError.Code, getErrorResponse(httpError, binder.translator))
+	return false
+}
+
+// Binds only the path params into dst, which is a pointer to the struct that is used as the `Path` section.
+// Unlike Bind, the struct isn't validated.
+func (binder Binder) BindPathInto(c echo.Context, dst interface{}) error {
+	return binder.bindSectionInto(pathField, c, dst)
+}
+
+// Binds only the query params into dst, which is a pointer to the struct that is used as the `Query` section.
+// Unlike Bind, the struct isn't validated.
+func (binder Binder) BindQueryInto(c echo.Context, dst interface{}) error {
+	return binder.bindSectionInto(queryField, c, dst)
+}
+
+// Binds only the headers into dst, which is a pointer to the struct that is used as the `Header` section.
+// Unlike Bind, the struct isn't validated.
+func (binder Binder) BindHeaderInto(c echo.Context, dst interface{}) error {
+	return binder.bindSectionInto(headerField, c, dst)
+}
+
+// Binds only the form params into dst, which is a pointer to the struct that is used as the `Form` section.
+// Unlike Bind, the struct isn't validated.
+func (binder Binder) BindFormInto(c echo.Context, dst interface{}) error {
+	return binder.bindSectionInto(formField, c, dst)
+}
+
+// Binds only the cookies into dst, which is a pointer to the struct that is used as the `Cookie` section.
+// Unlike Bind, the struct isn't validated.
+func (binder Binder) BindCookieInto(c echo.Context, dst interface{}) error {
+	return binder.bindSectionInto(cookieField, c, dst)
+}
+
+// Runs the handler of the section with dst as the section struct
+func (binder *Binder) bindSectionInto(section string, c echo.Context, dst interface{}) error {
+	dstType := reflect.TypeOf(dst)
+
+	// Make sure that we get a structure to bind
+	if dstType == nil || dstType.Kind() != reflect.Ptr || reflect.ValueOf(dst).IsNil() || dstType.Elem().Kind() != reflect.Struct {
+		return badRequestError(errorInvalidType)
+	}
+
+	structValue := reflect.ValueOf(dst).Elem()
+	if err := fieldHandlers[section](binder, c, dstType.Elem(), &structValue, &structValue); err != nil {
+		return binder.categoryError(err)
+	}
+
+	return nil
+}
+
+// Runs the structural checks of the binding over the definition of the request struct without a request, such as
+// unsupported field kinds, duplicate identifiers and invalid embedded fields, so mistakes can fail at startup (or in
+// tests) instead of at request time. i is either a struct or a pointer to one, its value doesn't matter.
+func (binder *Binder) ValidateSchema(i interface{}) error {
+	structType := reflect.TypeOf(i)
+	if structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return errorInvalidType
+	}
+
+	if field, ok := structType.FieldByName(bodySentFields); ok && field.Type != reflect.TypeOf(RecursiveLookupTable{}) {
+		return getInvalidTypeAtLocationError(bodySentFields, lookupTypeString)
+	}
+
+	for _, typeField := range getSectionFields(structType) {
+		if typeField.Name == bodyField {
+			// The body is decoded by its codec, so there is nothing to check in it
+			continue
+		}
+
+		sectionType := typeField.Type
+		if sectionType.Kind() == reflect.Ptr {
+			sectionType = sectionType.Elem()
+		}
+
+		if isPathMapSection(typeField) {
+			// The map gets all of the path params, so there are no fields to check
+			continue
+		}
+
+		if sectionType.Kind() != reflect.Struct {
+			return getInvalidTypeAtLocationError(typeField.Name, structTypeString)
+		}
+
+		if reflect.PtrTo(sectionType).Implements(sectionBinderType) {
+			// The section binds itself, so its fields are not walked
+			continue
+		}
+
+		if err := binder.validateSectionSchema(typeField.Name, sectionType, binder.sectionPrefixes[typeField.Name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Checks the schema of the section structure, and of the structs that are bound as elements of indexed slices
+func (binder *Binder) validateSectionSchema(location string, structType reflect.Type, prefix string) error {
+	schema, err := getStructSchema(binder.getSchemaKey(location, structType, prefix))
+	if err != nil {
+		return err
+	}
+
+	return schema.walk(structType, func(fieldType reflect.Type) error {
+		if !isStructSliceType(fieldType) {
+			return nil
+		}
+
+		elemType := fieldType.Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+
+		return binder.validateSectionSchema(location, elemType, "")
+	})
+}
+
+type structFieldData struct {
+	FieldName string
+	Value     *reflect.Value
+	Tag       reflect.StructTag
+	Options   tagOptions
+
+	// The section the field is bound from, and the identifier of the param it's bound from
+	location   string
+	identifier string
+
+	// Links the lazily allocated pointers leading to the field and reports whether they were linked, nil if there
+	// are none
+	allocate  func()
+	allocated func() bool
+
+	// The storage Value points to, so resolving a field doesn't allocate it separately
+	value reflect.Value
+}
+
+// Allocates the nil pointer structures that the field resides in, must be called before setting the field
+func (field *structFieldData) prepare() {
+	if field.allocate != nil {
+		field.allocate()
+	}
+}
+
+// Returns whether the field resides in a nil pointer structure that none of its other fields were bound into
+func (field *structFieldData) detached() bool {
+	return field.allocated != nil && !field.allocated()
+}
+
+var fieldHandlers = map[string]func(*Binder, echo.Context, reflect.Type, *reflect.Value, *reflect.Value) error{
+	pathField:       bindPath,
+	queryField:      bindQuery,
+	bodyField:       bindBody,
+	formField:       bindForm,
+	headerField:     bindHeader,
+	cookieField:     bindCookie,
+	fileField:       bindFile,
+	clientCertField: bindClientCert,
+	requestField:    bindRequest,
+}
+
+func bindPath(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	if structField.Kind() == reflect.Map {
+		return binder.bindPathMap(c, structField)
+	}
+
+	fields, err := binder.getStructFields(pathField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	names := c.ParamNames()
+	values := c.ParamValues()
+	bound := make(map[string]bool, len(names))
+
+	for i := 0; i < len(names) && i < len(values); i++ {
+		name := names[i]
+
+		field, ok := fields[name]
+		if !ok {
+			if binder.isSkippedField(pathField, structField, name) {
+				// The field of the path parameter is explicitly skipped with the `-` tag
+				continue
+			}
+
+			// Didn't found a field to bound to this path parameter, should return a bad request error.
+			return badRequestError(getMissingParamAtLocationError(pathField, name))
+		}
+
+		if values[i] == "" {
+			if field.Options.Has(requiredOption) {
+				return badRequestError(getRequiredParamAtLocationError(pathField, name))
+			}
+		} else {
+			bound[name] = true
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return badRequestError(getNotSettableParamAtLocationError(pathField, name))
+		}
+
+		if field.Value.Kind() == reflect.Slice {
+			// Slices get the segments of catch-all params (`/files/*`), unless the value is kept raw as a single element
+			segments := splitPathSegments(values[i])
+			if field.Options.Has(rawOption) && values[i] != "" {
+				segments = []string{values[i]}
+			}
+
+			if len(segments) > 0 {
+				if err := binder.setFieldValues(field, segments); err != nil {
+					return badRequestError(err)
+				}
+			}
+		} else {
+			field.prepare()
+			if err := binder.setValue(field, values[i], field.Value); err != nil {
+				return badRequestError(err)
+			}
+		}
+
+		binder.report.addField(pathField, name, field.FieldName, false)
+	}
+
+	// Required fields whose params aren't declared by the route are missing as well
+	for name, field := range fields {
+		if field.Options.Has(requiredOption) && !bound[name] {
+			return badRequestError(getRequiredParamAtLocationError(pathField, name))
+		}
+	}
+
+	return nil
+}
+
+// Returns whether the section is a `Path map[string]string` (or a pointer to one), which gets all of the path params
+func isPathMapSection(typeField reflect.StructField) bool {
+	sectionType := typeField.Type
+	if sectionType.Kind() == reflect.Ptr {
+		sectionType = sectionType.Elem()
+	}
+
+	if typeField.Name != pathField || sectionType.Kind() != reflect.Map {
+		return false
+	}
+
+	isSlice, ok := getStringMapKind(sectionType)
+	return ok && !isSlice
+}
+
+// Binds all of the path params of the route into the map of the `Path` section by their names, for generic routers
+// and proxies that don't know the params in advance. Routes without params leave the map untouched.
+func (binder *Binder) bindPathMap(c echo.Context, structField *reflect.Value) error {
+	names := c.ParamNames()
+	values := c.ParamValues()
+
+	if len(names) == 0 || len(values) == 0 {
+		return nil
+	}
+
+	if !structField.CanSet() {
+		return badRequestError(getNotSettableParamAtLocationError(pathField, pathField))
+	}
+
+	if structField.IsNil() {
+		structField.Set(reflect.MakeMapWithSize(structField.Type(), len(names)))
+	}
+
+	mapType := structField.Type()
+	for i := 0; i < len(names) && i < len(values); i++ {
+		structField.SetMapIndex(reflect.ValueOf(names[i]).Convert(mapType.Key()), reflect.ValueOf(values[i]).Convert(mapType.Elem()))
+		binder.report.addField(pathField, names[i], pathField, false)
+	}
+
+	return nil
+}
+
+// Splits the value of a catch-all path param into its segments, empty segments (of repeated slashes) are skipped
+func splitPathSegments(value string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(value, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+func bindQuery(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	// Check if the method is valid for the query binding, all of them are unless they were restricted
+	method := c.Request().Method
+	if binder.queryMethods != nil && !binder.queryMethods[method] {
+		return badRequestError(getUnsupportedHttpMethodError(queryField, method))
+	}
+
+	params := c.QueryParams()
+	if binder.maxQueryParams > 0 && len(params) > binder.maxQueryParams {
+		return badRequestError(getTooManyParamsAtLocationError(queryField, binder.maxQueryParams))
+	}
+
+	fields, err := binder.getStructFields(queryField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	rest := getOptionField(fields, restOption)
+
+	if raw := getOptionField(fields, rawOption); raw != nil {
+		// The raw field captures all of the params verbatim, while the other fields are still bound from them
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+
+		if err := setRestValues(queryField, raw, names, params); err != nil {
+			return badRequestError(err)
+		}
+	}
+
+	if binder.strictQuery || binder.report != nil || rest != nil {
+		unknown := getUnknownParams(fields, params)
+
+		if rest != nil {
+			// The unknown params are captured by the rest field instead of being skipped
+			if err := setRestValues(queryField, rest, unknown, params); err != nil {
+				return badRequestError(err)
+			}
+		} else {
+			binder.report.addSkippedParams(queryField, unknown)
+
+			if binder.strictQuery && len(unknown) > 0 {
+				return badRequestError(getUnknownParamAtLocationError(queryField, unknown[0]))
+			}
+		}
+	}
+
+	bound := make(map[string]bool, len(params))
+
+	for name, values := range params {
+		field, ok := fields[name]
+		if !ok || field == rest || isMapType(field.Value.Type()) || field.Options.Get(styleOption) == deepObjectStyle {
+			// Didn't found a field to bound to this query parameter (maps and deep objects are only bound from
+			// bracketed keys), continue
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return badRequestError(getNotSettableParamAtLocationError(queryField, name))
+		}
+
+		// A param that is sent without a value means true, while an explicit value still overrides it
+		if field.Options.Has(presenceOption) && len(values) == 1 && values[0] == "" {
+			values = []string{"true"}
+		}
+
+		if values, err = splitUnexplodedValues(field, values); err != nil {
+			return badRequestError(err)
+		}
+
+		if len(values) == 0 {
+			// Every element of the joined value was empty (`?ids=` or `?ids=,`), treat it as a param that wasn't sent
+			continue
+		}
+
+		if field.Options.Has(nestedQueryOption) {
+			// The value is a query string of its own, which is bound into the fields of the struct
+			if err := binder.setNestedQueryValues(field, name, values[0]); err != nil {
+				return badRequestError(err)
+			}
+		} else if field.Options.Has(rangeSeparatorOption) {
+			// The value holds both ends of a range, which are bound into the Start and End fields of the struct
+			if err := binder.setRangeValues(field, name, values[0]); err != nil {
+				return badRequestError(err)
+			}
+		} else if err := binder.setFieldValues(field, values); err != nil {
+			return badRequestError(err)
+		}
+
+		bound[name] = true
+		binder.report.addField(queryField, name, field.FieldName, false)
+	}
+
+	if err := binder.setIndexedStructValues(queryField, fields, params, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setMapValues(queryField, fields, params, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setDeepObjectValues(queryField, fields, params, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setGroupValues(queryField, fields, params, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setDefaultValues(c, queryField, fields, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	return nil
+}
+
+func bindBody(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) (err error) {
+	request := c.Request()
+
+	// Check if the method is valid for body binding and if there is content in the body
+	if request.Method == http.MethodGet {
+		return badRequestError(getUnsupportedHttpMethodError(bodyField, request.Method))
+	} else if request.ContentLength == 0 {
+		return nil
+	}
+
+	// Check if the content type is valid for body binding
+	contentType := request.Header.Get(echo.HeaderContentType)
+
+	if binder.canStreamBody(contentType, structType, structField) {
+		// Nothing else needs the raw body, so it's decoded while it's read instead of being buffered first
+		target := structField
+		if codecField, ok := getBodyCodecField(structField, contentType, binder.tagName); ok {
+			if codecField == nil {
+				return nil
+			}
+
+			target = codecField
+		}
+
+		if err := decodeJSONBody(request, target.Addr().Interface()); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return readBodyError(err)
+			}
+
+			return badRequestError(err)
+		}
+
+		binder.report.addField(bodyField, "", bodyField, false)
+		return nil
+	}
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		return readBodyError(err)
+	}
+
+	if binder.needsTranscoding(contentType) {
+		if body, err = binder.transcodeBody(contentType, body); err != nil {
+			return err
+		}
+	}
+
+	// Bodies of content types with a registered decoder are decoded by it, and their sent fields are not tracked
+	if decoder, ok := binder.bodyDecoders[getMediaType(contentType)]; ok {
+		target := structField.Addr()
+		if structField.Kind() == reflect.Ptr {
+			if structField.IsNil() {
+				structField.Set(reflect.New(structField.Type().Elem()))
+			}
+
+			target = *structField
+		}
+
+		if err := decoder(body, target.Interface()); err != nil {
+			return badRequestError(err)
+		}
+
+		binder.report.addField(bodyField, "", bodyField, false)
+		return nil
+	}
+
+	// When the body declares a field per codec, only the field that matches the content type is decoded
+	target := structField
+	if codecField, ok := getBodyCodecField(structField, contentType, binder.tagName); ok {
+		if codecField == nil {
+			return nil
+		}
+
+		target = codecField
+	}
+
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
+		if err := binder.unmarshalJSONBody(body, target.Addr().Interface()); err != nil {
+			return badRequestError(err)
+		}
+
+		binder.report.addField(bodyField, "", bodyField, false)
+
+	case strings.HasPrefix(contentType, mimeApplicationMergePatchJSON):
+		// The patch is decoded into a zero value first so it's rejected just like a JSON body would be (mismatched
+		// types, unknown fields of the strict body), and only then merged onto the (possibly pre-populated) body
+		if err := binder.unmarshalJSONBody(body, reflect.New(target.Type()).Interface()); err != nil {
+			return badRequestError(err)
+		}
+
+		if err := mergeJSONPatch(*target, body); err != nil {
+			return badRequestError(err)
+		}
+
+		binder.report.addField(bodyField, "", bodyField, false)
+
+	case strings.HasPrefix(contentType, echo.MIMEApplicationXML), strings.HasPrefix(contentType, echo.MIMETextXML):
+		if err := xml.Unmarshal(body, target.Addr().Interface()); err != nil {
+			return badRequestError(err)
+		}
+
+		binder.report.addField(bodyField, "", bodyField, false)
+
+	case isYAMLContentType(contentType):
+		if err := yaml.Unmarshal(body, target.Addr().Interface()); err != nil {
+			return badRequestError(err)
+		}
+
+		binder.report.addField(bodyField, "", bodyField, false)
+	}
+
+	if structField.Type().Kind() != reflect.Struct || binder.disableBodySentFields {
+		// If the body is not a struct (or the tracking is disabled), no need to fill the BodySentFields field.
+		return nil
+	}
+
+	fieldValue, err := getBodySentFieldsValue(structType, structValue)
+	if err != nil || fieldValue == nil {
+		return err
+	}
+
+	data, err := getBodySentFields(c, contentType, body)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	fieldValue.Set(reflect.ValueOf(data.IntoRecursiveLookupTable()))
+	return nil
+}
+
+// Returns whether the JSON body can be decoded straight from the request, which is the case when nothing needs its
+// raw bytes: the sent fields aren't tracked, the body isn't strict (whose unknown fields are collected from the bytes)
+// and there is no signature secret (since the signatures of the body are verified against the bytes).
+func (binder *Binder) canStreamBody(contentType string, structType reflect.Type, structField *reflect.Value) bool {
+	if !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) || binder.strictBody || len(binder.signatureSecret) > 0 {
+		return false
+	} else if binder.needsTranscoding(contentType) {
+		return false
+	} else if _, ok := binder.bodyDecoders[getMediaType(contentType)]; ok {
+		return false
+	}
+
+	if structField.Kind() != reflect.Struct || binder.disableBodySentFields {
+		return true
+	}
+
+	_, tracked := structType.FieldByName(bodySentFields)
+	return !tracked
+}
+
+// Decodes the JSON body of the request into i while it's read, just like json.Unmarshal there must be nothing after
+// the value. The reading stops once the context of the request is done, just like readRequestBody.
+func decodeJSONBody(request *http.Request, i interface{}) error {
+	decoder := json.NewDecoder(&contextReader{ctx: request.Context(), reader: request.Body})
+	if err := decoder.Decode(i); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return errorTrailingBodyData
+	}
+
+	return nil
+}
+
+// Returns the BodySentFields field of the request struct, or nil if it doesn't declare one
+func getBodySentFieldsValue(structType reflect.Type, structValue *reflect.Value) (*reflect.Value, error) {
+	field, found := structType.FieldByName(bodySentFields)
+	if !found {
+		// Didn't found the body sent field, so we just don't bind it.
+		return nil, nil
+	} else if field.Type != reflect.TypeOf(RecursiveLookupTable{}) {
+		return nil, badRequestError(getInvalidTypeAtLocationError(bodySentFields, lookupTypeString))
+	}
+
+	fieldValue := structValue.FieldByName(bodySentFields)
+	if !fieldValue.CanSet() {
+		return nil, badRequestError(getNotSettableParamAtLocationError(structValue.Type().Name(), bodySentFields))
+	}
+
+	return &fieldValue, nil
+}
+
+// Returns the fields that were sent in the body by its content type: the members of JSON and YAML objects, the nested
+// elements (and attributes) of the XML root element, and the keys of forms
+func getBodySentFields(c echo.Context, contentType string, body []byte) (lookupTable, error) {
+	data := lookupTable{}
+
+	switch {
+	case isYAMLContentType(contentType):
+		err := yaml.Unmarshal(body, &data)
+		return data, err
+
+	case strings.HasPrefix(contentType, echo.MIMEApplicationXML), strings.HasPrefix(contentType, echo.MIMETextXML):
+		return getXMLLookupTable(body)
+
+	case strings.HasPrefix(contentType, echo.MIMEApplicationForm), strings.HasPrefix(contentType, echo.MIMEMultipartForm):
+		params, err := c.FormParams()
+		if err != nil {
+			return nil, err
+		}
+
+		return getFormLookupTable(params), nil
+	}
+
+	err := json.Unmarshal(body, &data)
+	return data, err
+}
+
+// Unmarshals the JSON body into i, rejecting unknown fields when the body is strict
+func (binder *Binder) unmarshalJSONBody(body []byte, i interface{}) error {
+	if !binder.strictBody {
+		return json.Unmarshal(body, i)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(i); err != nil {
+		// The decoder stops at the first unknown field, so all of them are collected from the sent fields to
+		// report them at once
+		if strings.HasPrefix(err.Error(), "json: unknown field ") {
+			if unknown := getUnknownBodyFields(body, reflect.TypeOf(i).Elem()); len(unknown) > 0 {
+				return getUnknownFieldsAtLocationError(bodyField, unknown)
+			}
+		}
+
+		return err
+	}
+
+	// Just like json.Unmarshal, there must be nothing after the value
+	if _, err := decoder.Token(); err != io.EOF {
+		return errorTrailingBodyData
+	}
+
+	return nil
+}
+
+// Returns the fields of the JSON body that don't exist in the body type
+func getUnknownBodyFields(body []byte, bodyType reflect.Type) []string {
+	if bodyType.Kind() == reflect.Ptr {
+		bodyType = bodyType.Elem()
+	}
+
+	data := lookupTable{}
+	if bodyType.Kind() != reflect.Struct || json.Unmarshal(body, &data) != nil {
+		return nil
+	}
+
+	return getUnknownJSONFields(bodyType, data.IntoRecursiveLookupTable(), "")
+}
+
+// Returns whether the content type is one of the YAML media types (`application/yaml`, `application/x-yaml` or `text/yaml`)
+func isYAMLContentType(contentType string) bool {
+	switch getMediaType(contentType) {
+	case mimeApplicationYAML, mimeApplicationXYAML, mimeTextYAML:
+		return true
+	}
+
+	return false
+}
+
+// Returns the media type of the content type, without its parameters (such as the charset)
+func getMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// Returns the field of the body that is tagged with the codec of the content type (`binder:",json"` or `binder:",xml"`).
+// The second return value reports whether the body declares codec fields at all, if it does but none of them
+// matches the content type the returned field is nil.
+func getBodyCodecField(structField *reflect.Value, contentType, tagName string) (*reflect.Value, bool) {
+	if structField.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	codec := ""
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON), strings.HasPrefix(contentType, mimeApplicationMergePatchJSON):
+		codec = jsonOption
+
+	case strings.HasPrefix(contentType, echo.MIMEApplicationXML), strings.HasPrefix(contentType, echo.MIMETextXML):
+		codec = xmlOption
+	}
+
+	var target *reflect.Value
+	hasCodecFields := false
+
+	for i := 0; i < structField.NumField(); i++ {
+		_, options := parseTag(structField.Type().Field(i).Tag.Get(tagName))
+		if !options.Has(jsonOption) && !options.Has(xmlOption) {
+			continue
+		}
+
+		hasCodecFields = true
+		if codec != "" && options.Has(codec) && target == nil && structField.Field(i).CanSet() {
+			field := structField.Field(i)
+			target = &field
+		}
+	}
+
+	return target, hasCodecFields
+}
+
+func bindForm(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	request := c.Request()
+
+	// Check if the method is valid for body binding
+	if request.Method == http.MethodGet {
+		return badRequestError(getUnsupportedHttpMethodError(bodyField, request.Method))
+	}
+
+	fields, err := binder.getStructFields(formField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	// Check if there is content in the body and if the content type is valid for form binding,
+	// without a form only the default values are bound
+	contentType := request.Header.Get(echo.HeaderContentType)
+	params := url.Values{}
+	if request.ContentLength != 0 && (strings.HasPrefix(contentType, echo.MIMEApplicationForm) || strings.HasPrefix(contentType, echo.MIMEMultipartForm)) {
+		if params, err = c.FormParams(); err != nil {
+			return badRequestError(err)
+		}
+
+		// The keys of the form are the fields that were sent in the body
+		fieldValue, err := getBodySentFieldsValue(structType, structValue)
+		if err != nil {
+			return err
+		}
+
+		if fieldValue != nil && !binder.disableBodySentFields {
+			data := getFormLookupTable(params)
+			fieldValue.Set(reflect.ValueOf(data.IntoRecursiveLookupTable()))
+		}
+	}
+
+	if binder.strictForm || binder.report != nil {
+		unknown := getUnknownParams(fields, params)
+		binder.report.addSkippedParams(formField, unknown)
+
+		if binder.strictForm && len(unknown) > 0 {
+			return badRequestError(getUnknownParamAtLocationError(formField, unknown[0]))
+		}
+	}
+
+	bound := make(map[string]bool, len(params))
+
+	for name, values := range params {
+		field, ok := fields[name]
+		if !ok || isMapType(field.Value.Type()) || field.Value.Type() == multipartType {
+			// Didn't found a field to bound to this form parameter (maps are only bound from bracketed keys), continue
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return badRequestError(getNotSettableParamAtLocationError(formField, name))
+		}
+
+		if values, err = splitUnexplodedValues(field, values); err != nil {
+			return badRequestError(err)
+		}
+
+		if len(values) == 0 {
+			// Every element of the joined value was empty (`?ids=` or `?ids=,`), treat it as a param that wasn't sent
+			continue
+		}
+
+		if err := binder.setFieldValues(field, values); err != nil {
+			return badRequestError(err)
+		}
+
+		bound[name] = true
+		binder.report.addField(formField, name, field.FieldName, false)
+	}
+
+	if request.ContentLength != 0 && strings.HasPrefix(contentType, echo.MIMEMultipartForm) {
+		form, err := c.MultipartForm()
+		if err != nil {
+			return badRequestError(err)
+		}
+
+		if err := binder.setFormFileValues(fields, form.File, bound); err != nil {
+			return badRequestError(err)
+		}
+
+		if err := binder.setMultipartFormValues(formField, fields, form); err != nil {
+			return badRequestError(err)
+		}
+	}
+
+	if err := binder.setIndexedStructValues(formField, fields, params, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setMapValues(formField, fields, params, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setDefaultValues(c, formField, fields, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	return nil
+}
+
+// Sets the file parts of a multipart form into the *multipart.FileHeader and []*multipart.FileHeader fields of the form,
+// unlike the File section a single file field fails the binding when more than one file is sent for it
+func (binder *Binder) setFormFileValues(fields map[string]*structFieldData, files map[string][]*multipart.FileHeader, bound map[string]bool) error {
+	for name, headers := range files {
+		field, ok := fields[name]
+		if !ok || len(headers) == 0 {
+			continue
+		}
+
+		switch field.Value.Type() {
+		case fileHeaderType:
+			if len(headers) > 1 {
+				return getTooManyFilesAtLocationError(formField, name, len(headers))
+			}
+
+		case reflect.TypeOf(headers):
+
+		default:
+			// Not a file field, the part can't be bound into it
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return getNotSettableParamAtLocationError(formField, name)
+		}
+
+		field.prepare()
+		if field.Value.Kind() == reflect.Ptr {
+			field.Value.Set(reflect.ValueOf(headers[0]))
+		} else {
+			field.Value.Set(reflect.ValueOf(headers))
+		}
+
+		bound[name] = true
+		binder.report.addField(formField, name, field.FieldName, false)
+	}
+
+	return nil
+}
+
+func bindHeader(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	fields, err := binder.getStructFields(headerField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	header := c.Request().Header
+
+	// The fields that weren't sent and reside in nil pointer structures are deferred to the second pass
+	deferred := map[string]bool{}
+
+	for pass := 0; pass < 2; pass++ {
+		for name, field := range fields {
+			if pass > 0 && !deferred[name] {
+				continue
+			}
+
+			if field.Options.Has(indexedOption) {
+				// The identifier is a prefix of headers that are followed by an index
+				values := transformHeaderValues(field.Options, getIndexedHeaderValues(header, name))
+				if len(values) == 0 {
+					continue
+				}
+
+				if !field.Value.CanSet() {
+					// The field is not settable, should return an error
+					return badRequestError(getNotSettableParamAtLocationError(headerField, field.FieldName))
+				}
+
+				if err := binder.setFieldValues(field, values); err != nil {
+					return badRequestError(err)
+				}
+
+				binder.report.addField(headerField, name, field.FieldName, false)
+				continue
+			}
+
+			if field.Options.Has(basicOption) {
+				// The header holds basic credentials, which are split into the Username and Password fields
+				headerValues := binder.getHeaderValues(header, name)
+				if len(headerValues) == 0 || headerValues[0] == "" {
+					continue
+				}
+
+				headerValue := headerValues[0]
+
+				if !field.Value.CanSet() {
+					// The field is not settable, should return an error
+					return badRequestError(getNotSettableParamAtLocationError(headerField, field.FieldName))
+				}
+
+				username, password, err := parseBasicAuth(headerValue)
+				if err != nil {
+					return badRequestError(getMalformedParamAtLocationError(headerField, name, err))
+				}
+
+				if !setBasicAuthFields(field, username, password) {
+					return badRequestError(getInvalidTypeAtLocationError(headerField+"."+field.FieldName, basicAuthTypeString))
+				}
+
+				binder.report.addField(headerField, name, field.FieldName, false)
+				continue
+			}
+
+			headerValues := binder.getHeaderValues(header, name)
+			if field.Options.Has(decryptOption) {
+				// The values are encrypted, so they are decrypted before anything else is done with them
+				if binder.headerDecryptor == nil {
+					return internalServerError(errorMissingHeaderDecryptor)
+				}
+
+				if headerValues, err = decryptHeaderValues(binder.headerDecryptor, name, headerValues); err != nil {
+					return badRequestError(err)
+				}
+			}
+
+			if !field.Options.Has(hmacOption) {
+				headerValues = transformHeaderValues(field.Options, headerValues)
+			}
+
+			headerValue := ""
+			if len(headerValues) > 0 {
+				headerValue = headerValues[0]
+			}
+
+			if headerValue == "" && field.Options.Has(generateOption) {
+				generator, ok := binder.generators[field.Options.Get(generateOption)]
+				if !ok {
+					return internalServerError(getUnknownGeneratorError(headerField, field.Options.Get(generateOption)))
+				}
+
+				if headerValue, err = generator(); err != nil {
+					return internalServerError(err)
+				}
+
+				if binder.writeGeneratedHeaders {
+					c.Response().Header().Set(name, headerValue)
+				}
+			}
+
+			if field.Options.Has(hmacOption) {
+				// The header holds the signature of the body, which must be verified before anything else is trusted
+				if len(binder.signatureSecret) == 0 {
+					return internalServerError(errorMissingSignatureSecret)
+				}
+
+				body, err := readRequestBody(c.Request())
+				if err != nil {
+					return readBodyError(err)
+				}
+
+				if !verifyHMACSignature(binder.signatureSecret, body, headerValue) {
+					return badRequestError(getInvalidSignatureAtLocationError(headerField, name))
+				}
+			}
+
+			isDefault := false
+			if headerValue == "" || (binder.ignoreNullStringOnHeader && headerValue == "null") {
+				if field.detached() {
+					// Defaults don't allocate nil pointer structures on their own, so the field is bound after the
+					// sent headers, and only when one of them linked the structure
+					if pass == 0 {
+						deferred[name] = true
+					}
+
+					continue
+				}
+
+				defaultValue, ok, err := binder.getDefaultValue(c, headerField, name, field)
+				if err != nil {
+					return err
+				} else if !ok && field.Options.Has(absentOption) {
+					// Absent headers are explicitly false, so *bool fields aren't left nil
+					if !isBoolType(field.Value.Type()) {
+						return internalServerError(getInvalidTypeAtLocationError(headerField+"."+field.FieldName, boolTypeString))
+					}
+
+					defaultValue = "false"
+				} else if !ok {
+					continue
+				}
+
+				headerValue = defaultValue
+				isDefault = true
+			}
+
+			if !field.Value.CanSet() {
+				// The field is not settable, should return an error
+				return badRequestError(getNotSettableParamAtLocationError(headerField, field.FieldName))
+			}
+
+			if field.Options.Has(etagsOption) {
+				// The header holds a list of entity tags, []ETag fields get the tags and their weakness while other
+				// fields get the opaque tags without the quotes
+				etags, err := parseETags(strings.Join(headerValues, ","))
+				if err != nil {
+					return badRequestError(getMalformedParamAtLocationError(headerField, name, err))
+				}
+
+				if len(etags) == 0 {
+					continue
+				}
+
+				if field.Value.Type() == reflect.TypeOf(etags) {
+					field.prepare()
+					field.Value.Set(reflect.ValueOf(etags))
+				} else {
+					values := make([]string, len(etags))
+					for i, etag := range etags {
+						values[i] = etag.Value
+					}
+
+					if err := binder.setFieldValues(field, values); err != nil {
+						return badRequestError(err)
+					}
+				}
+
+				binder.report.addField(headerField, name, field.FieldName, isDefault)
+				continue
+			}
+
+			if field.Options.Has(byteRangeOption) {
+				// The header holds byte ranges, []ByteRange fields get all of them while ByteRange fields get a single one
+				ranges, err := parseByteRanges(headerValue)
+				if err != nil {
+					return badRequestError(getMalformedParamAtLocationError(headerField, name, err))
+				}
+
+				if err := setByteRanges(field, ranges); err != nil {
+					return badRequestError(err)
+				}
+
+				binder.report.addField(headerField, name, field.FieldName, isDefault)
+				continue
+			}
+
+			if field.Options.Has(qvaluesOption) {
+				// Slices get all of the values ordered by their quality, and other kinds get the best one
+				values, err := parseQValues(strings.Join(headerValues, ","))
+				if err != nil {
+					return badRequestError(getMalformedParamAtLocationError(headerField, name, err))
+				}
+
+				if len(values) == 0 {
+					continue
+				}
+
+				if err := binder.setFieldValues(field, values); err != nil {
+					return badRequestError(err)
+				}
+
+				binder.report.addField(headerField, name, field.FieldName, isDefault)
+				continue
+			}
+
+			field.prepare()
+			if err := binder.setValue(field, headerValue, field.Value); err != nil {
+				return badRequestError(err)
+			}
+
+			binder.report.addField(headerField, name, field.FieldName, isDefault)
+		}
+	}
+
+	return nil
+}
+
+func bindCookie(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	fields, err := binder.getStructFields(cookieField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	bound := make(map[string]bool, len(fields))
+
+	for name, field := range fields {
+		cookie, err := c.Cookie(name)
+		if err != nil {
+			// The cookie wasn't sent, skip it
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return badRequestError(getNotSettableParamAtLocationError(cookieField, name))
+		}
+
+		if err := binder.setFieldValues(field, []string{cookie.Value}); err != nil {
+			return badRequestError(err)
+		}
+
+		bound[name] = true
+		binder.report.addField(cookieField, name, field.FieldName, false)
+	}
+
+	if err := binder.setDefaultValues(c, cookieField, fields, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	return nil
+}
+
+func bindFile(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	request := c.Request()
+
+	// Files can only be sent by a multipart form
+	if request.Method == http.MethodGet {
+		return badRequestError(getUnsupportedHttpMethodError(fileField, request.Method))
+	} else if !strings.HasPrefix(request.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return nil
+	}
+
+	fields, err := binder.getStructFields(fileField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	if err := binder.setMultipartFormValues(fileField, fields, form); err != nil {
+		return badRequestError(err)
+	}
+
+	for name, files := range form.File {
+		field, ok := fields[name]
+		if !ok || len(files) == 0 || field.Value.Type() == multipartType {
+			// Didn't found a field to bound to this file, continue
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return badRequestError(getNotSettableParamAtLocationError(fileField, name))
+		}
+
+		switch field.Value.Type() {
+		case fileHeaderType:
+			field.prepare()
+			field.Value.Set(reflect.ValueOf(files[0]))
+
+		case reflect.TypeOf(files):
+			field.prepare()
+			field.Value.Set(reflect.ValueOf(files))
+
+		case fileBytesType:
+			content, err := binder.readFormFile(name, files[0])
+			if err != nil {
+				return err
+			}
+
+			field.prepare()
+			field.Value.SetBytes(content)
+
+		case readerType:
+			file, err := files[0].Open()
+			if err != nil {
+				return internalServerError(err)
+			}
+
+			field.prepare()
+			field.Value.Set(reflect.ValueOf(file))
+
+		default:
+			return badRequestError(getInvalidTypeAtLocationError(fileField+"."+field.FieldName, fileHeaderTypeString))
+		}
+
+		binder.report.addField(fileField, name, field.FieldName, false)
+	}
+
+	return nil
+}
+
+// Sets the whole parsed form into the *multipart.Form fields of the section, for handlers that need all of its values
+// and files instead of the ones that are bound into the other fields
+func (binder *Binder) setMultipartFormValues(location string, fields map[string]*structFieldData, form *multipart.Form) error {
+	for name, field := range fields {
+		if field.Value.Type() != multipartType {
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return getNotSettableParamAtLocationError(location, name)
+		}
+
+		field.prepare()
+		field.Value.Set(reflect.ValueOf(form))
+		binder.report.addField(location, name, field.FieldName, false)
+	}
+
+	return nil
+}
+
+// Reads the whole content of the file of the param, files that are larger than the max file size fail the binding
+func (binder *Binder) readFormFile(name string, header *multipart.FileHeader) ([]byte, error) {
+	if binder.maxFileSize > 0 && header.Size > binder.maxFileSize {
+		return nil, fileTooLargeError(getFileTooLargeAtLocationError(fileField, name, binder.maxFileSize))
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, internalServerError(err)
+	}
+	defer file.Close()
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, internalServerError(err)
+	}
+
+	return content, nil
+}
+
+// Sets the byte ranges into a []ByteRange field, or into a ByteRange field (or a pointer to one) when there is a
+// single range
+func setByteRanges(field *structFieldData, ranges []ByteRange) error {
+	valueType := field.Value.Type()
+
+	switch {
+	case valueType == reflect.TypeOf(ranges):
+		field.prepare()
+		field.Value.Set(reflect.ValueOf(ranges))
+
+	case valueType == byteRangeType || (valueType.Kind() == reflect.Ptr && valueType.Elem() == byteRangeType):
+		if len(ranges) > 1 {
+			return getTooManyValuesAtLocationError(headerField, field.identifier, 1, len(ranges))
+		}
+
+		field.prepare()
+		if valueType.Kind() == reflect.Ptr {
+			field.Value.Set(reflect.ValueOf(&ranges[0]))
+		} else {
+			field.Value.Set(reflect.ValueOf(ranges[0]))
+		}
+
+	default:
+		return getInvalidTypeAtLocationError(headerField+"."+field.FieldName, byteRangesTypeString)
+	}
+
+	return nil
+}
+
+// Binds the attributes of the TLS client certificate (the first peer certificate) into the fields by their identifiers,
+// requests without a client certificate leave the fields untouched (except for their default values).
+func bindClientCert(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	fields, err := binder.getStructFields(clientCertField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	params := map[string][]string{}
+	if state := c.Request().TLS; state != nil && len(state.PeerCertificates) > 0 {
+		params = getClientCertValues(state.PeerCertificates[0])
+	}
+
+	return binder.setAttributeValues(c, clientCertField, fields, params)
+}
+
+// Binds the metadata of the request, such as the pattern of the matched route, into the fields by their identifiers.
+func bindRequest(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
+	fields, err := binder.getStructFields(requestField, structField)
+	if err != nil {
+		return badRequestError(err)
+	}
+
+	return binder.setAttributeValues(c, requestField, fields, getRequestValues(c))
+}
+
+// Sets the values of the attributes (of sections that aren't sent by the client, like the client certificate) into
+// the fields by their identifiers, and the defaults of the fields whose attributes have no value.
+func (binder *Binder) setAttributeValues(c echo.Context, location string, fields map[string]*structFieldData, params map[string][]string) error {
+	bound := make(map[string]bool, len(fields))
+
+	for name, values := range params {
+		field, ok := fields[name]
+		if !ok || len(values) == 0 {
+			// Didn't found a field to bound to this attribute, or there is no value for it
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return badRequestError(getNotSettableParamAtLocationError(location, name))
+		}
+
+		if err := binder.setFieldValues(field, values); err != nil {
+			return badRequestError(err)
+		}
+
+		bound[name] = true
+		binder.report.addField(location, name, field.FieldName, false)
+	}
+
+	if err := binder.setDefaultValues(c, location, fields, bound); err != nil {
+		return badRequestError(err)
+	}
+
+	return nil
+}
+
+// Reads the whole body of the request, and restores it so it can be read again by the other sections.
+// The reading stops once the context of the request is canceled or its deadline is exceeded, so slow clients
+// can't hold the binding forever.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(&contextReader{ctx: request.Context(), reader: request.Body})
+	if err != nil {
+		return nil, err
+	}
+
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// A reader that fails with the error of the context once it is done
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := r.reader.Read(p)
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		return n, ctxErr
+	}
+
+	return n, err
+}
+
+// Returns the values of the header, either by its canonical key or by the lowercased key when lowercase lookup is enabled
+func (binder *Binder) getHeaderValues(header http.Header, name string) []string {
+	if binder.lowercaseHeaderLookup {
+		return header[strings.ToLower(name)]
+	}
+
+	return header.Values(name)
+}
+
+// Returns a map of string to reflect.StructField out of a reflect.Value, location is the section that is being bound
+// This function assumes that the reflect.Value is a struct, and it will panic if it is not
+func (binder *Binder) getStructFields(location string, structField *reflect.Value) (map[string]*structFieldData, error) {
+	return binder.resolveStructFields(binder.getSchemaKey(location, structField.Type(), binder.sectionPrefixes[location]), structField)
+}
+
+// Returns whether the section has a field with the name that is skipped with the `-` tag
+func (binder *Binder) isSkippedField(location string, structField *reflect.Value, name string) bool {
+	schema, err := getStructSchema(binder.getSchemaKey(location, structField.Type(), binder.sectionPrefixes[location]))
+	return err == nil && schema.skipped[name]
+}
+
+// Returns the fields of a structure that is nested in a section (such as the elements of indexed slices), their
+// identifiers are relative to the key they are nested under so the prefix of the section doesn't apply to them
+func (binder *Binder) getNestedStructFields(location string, structField *reflect.Value) (map[string]*structFieldData, error) {
+	return binder.resolveStructFields(binder.getSchemaKey(location, structField.Type(), ""), structField)
+}
+
+// Resolves the fields of the structure by their identifiers, using the schema of the key
+func (binder *Binder) resolveStructFields(key schemaKey, structField *reflect.Value) (map[string]*structFieldData, error) {
+	schema, err := getStructSchema(key)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]*structFieldData, schema.size)
+	data := make([]structFieldData, 0, schema.size)
+	schema.resolve(*structField, nil, nil, fields, &data)
+
+	return fields, nil
+}
+
+// Returns whether a struct typed field should be bound as a single value instead of walking its fields
+func isLeafType(fieldType reflect.Type, options tagOptions) bool {
+	if options.Has(jsonOption) || options.Has(basicOption) || options.Has(nestedQueryOption) || options.Has(etagsOption) ||
+		options.Has(rangeSeparatorOption) || options.Has(byteRangeOption) || options.Get(styleOption) == deepObjectStyle {
+		return true
+	}
+
+	// Scanners are bound as a whole, even the ones that are structs (such as sql.NullString)
+	if isScannerType(fieldType) {
+		return true
+	}
+
+	switch fieldType {
+	case fileHeaderType, fileHeaderType.Elem(), multipartType, timeType, reflect.PtrTo(timeType), regexpType, reflect.PtrTo(regexpType):
+		return true
+	}
+
+	return false
+}
+
+// Returns the kind that can't be bound from a string value, if the type (or its elements) is of such kind.
+// Types that implement one of the unmarshaler interfaces are always supported.
+func getUnsupportedKind(fieldType reflect.Type) (reflect.Kind, bool) {
+	for {
+		if isUnmarshalerType(fieldType) {
+			return reflect.Invalid, false
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			fieldType = fieldType.Elem()
+
+		case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+			return fieldType.Kind(), true
+
+		default:
+			return reflect.Invalid, false
+		}
+	}
+}
+
+// Sets the values of a query/form param into the field, slices get all of the values while other kinds get the first one
+func (binder *Binder) setFieldValues(field *structFieldData, values []string) error {
+	if len(values) == 0 {
+		// There is nothing to bind, keep the current value of the field
+		return nil
+	}
+
+	if field.Options.Has(jsonOption) {
+		// The value is a JSON document that should be decoded into the field as a whole
+		field.prepare()
+		return json.Unmarshal([]byte(values[0]), field.Value.Addr().Interface())
+	}
+
+	if field.Options.Has(jsonArrayOption) {
+		// The value is a JSON array (`[1,2,3]`) of the elements of the slice
+		if field.Value.Kind() != reflect.Slice {
+			return getInvalidTypeAtLocationError(field.location+"."+field.FieldName, sliceTypeString)
+		}
+
+		slice := reflect.New(field.Value.Type())
+		if err := json.Unmarshal([]byte(values[0]), slice.Interface()); err != nil {
+			return getMalformedParamAtLocationError(field.location, field.identifier, err)
+		}
+
+		field.prepare()
+		field.Value.Set(slice.Elem())
+		return nil
+	}
+
+	if kind := field.Value.Kind(); (kind == reflect.Slice || kind == reflect.Array) && (isUnmarshalerType(field.Value.Type()) || field.Value.Type() == fileBytesType || binder.hasConverter(field.Value.Type())) {
+		// Slices with an unmarshaler (`type Tags []string` with UnmarshalText) or a converter of their own (such as
+		// `[16]byte` UUIDs) unmarshal the whole value, and bytes are sent as a single encoded value (such as
+		// signatures and nonces) instead of a value per byte
+		field.prepare()
+		return binder.setValue(field, values[0], field.Value)
+	}
+
+	switch field.Value.Type().Kind() {
+	case reflect.Slice:
+		if field.Options.Has(maxOption) {
+			// Only the first values are bound, the rest are silently dropped
+			max, err := strconv.Atoi(field.Options.Get(maxOption))
+			if err != nil || max < 0 {
+				return internalServerError(getInvalidOptionValueError(field.location, field.FieldName, maxOption, field.Options.Get(maxOption)))
+			}
+
+			if len(values) > max {
+				values = values[:max]
+			}
+		}
+
+		slice := reflect.MakeSlice(field.Value.Type(), len(values), len(values))
+
+		// Build the slice with the values
+		for i := 0; i < len(values); i++ {
+			value := slice.Index(i)
+			if err := binder.setValue(field, values[i], &value); err != nil {
+				if binder.hasConverter(value.Type()) {
+					// The errors of the converters already describe the field
+					return err
+				}
+
+				return getInvalidElementAtLocationError(field.location, field.identifier, i, values[i], value.Type(), err)
+			}
+		}
+
+		// Set the slice to the field
+		field.prepare()
+		field.Value.Set(slice)
+
+	case reflect.Array:
+		array := reflect.New(field.Value.Type()).Elem()
+		if len(values) > array.Len() {
+			if !field.Options.Has(truncateOption) {
+				return getTooManyValuesAtLocationError(field.location, field.identifier, array.Len(), len(values))
+			}
+
+			values = values[:array.Len()]
+		}
+
+		// Fill the first elements of the array, the rest are left zero
+		for i := 0; i < len(values); i++ {
+			value := array.Index(i)
+			if err := binder.setValue(field, values[i], &value); err != nil {
+				if binder.hasConverter(value.Type()) {
+					return err
+				}
+
+				return getInvalidElementAtLocationError(field.location, field.identifier, i, values[i], value.Type(), err)
+			}
+		}
+
+		field.prepare()
+		field.Value.Set(array)
+
+	default:
+		field.prepare()
+		if err := binder.setValue(field, values[0], field.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Binds params with indexed keys (for example `users[0].name=a&users[1].name=b`) into slices of structs,
+// the slice is as long as the highest index that was sent, and pointer elements are only allocated for the sent indexes.
+func (binder *Binder) setIndexedStructValues(location string, fields map[string]*structFieldData, params url.Values, bound map[string]bool) error {
+	elements := map[string]map[int]url.Values{}
+
+	for key, values := range params {
+		name, index, subKey, ok := parseIndexedKey(key)
+		if !ok {
+			continue
+		}
+
+		field, ok := fields[name]
+		if !ok || !isStructSliceType(field.Value.Type()) {
+			continue
+		}
+
+		if index >= maxIndexedSliceLength {
+			return getIndexOutOfRangeError(location, key, maxIndexedSliceLength)
+		}
+
+		if elements[name] == nil {
+			elements[name] = map[int]url.Values{}
+		}
+
+		if elements[name][index] == nil {
+			elements[name][index] = url.Values{}
+		}
+
+		elements[name][index][subKey] = values
+	}
+
+	for name, indexes := range elements {
+		field := fields[name]
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return getNotSettableParamAtLocationError(location, name)
+		}
+
+		length := 0
+		for index := range indexes {
+			if index >= length {
+				length = index + 1
+			}
+		}
+
+		sliceType := field.Value.Type()
+		slice := reflect.MakeSlice(sliceType, length, length)
+
+		for index, values := range indexes {
+			element := slice.Index(index)
+			if sliceType.Elem().Kind() == reflect.Ptr {
+				element.Set(reflect.New(sliceType.Elem().Elem()))
+				element = element.Elem()
+			}
+
+			elementFields, err := binder.getNestedStructFields(location, &element)
+			if err != nil {
+				return err
+			}
+
+			for subKey, subValues := range values {
+				elementField, ok := elementFields[subKey]
+				if !ok {
+					// Didn't found a field to bound to this param, continue
+					continue
+				}
+
+				if err := binder.setFieldValues(elementField, subValues); err != nil {
+					return err
+				}
+			}
+		}
+
+		field.prepare()
+		field.Value.Set(slice)
+		bound[name] = true
+		binder.report.addField(location, name, field.FieldName, false)
+	}
+
+	return nil
+}
+
+// Binds params with bracketed keys (for example `filter[status]=open`) into map fields whose identifier is the prefix.
+// Maps (and pointers to maps) are allocated when nil, and their values are either strings or slices of strings.
+func (binder *Binder) setMapValues(location string, fields map[string]*structFieldData, params url.Values, bound map[string]bool) error {
+	for key, values := range params {
+		name, subKey, ok := parseBracketedKey(key)
+		if !ok {
+			continue
+		}
+
+		field, ok := fields[name]
+		if !ok || field.Options.Has(restOption) || field.Options.Has(rawOption) || field.Options.Has(groupOption) {
+			continue
+		}
+
+		mapType := field.Value.Type()
+		if mapType.Kind() == reflect.Ptr {
+			mapType = mapType.Elem()
+		}
+
+		if mapType.Kind() != reflect.Map {
+			continue
+		}
+
+		elemType := mapType.Elem()
+		isSlice, ok := getStringMapKind(mapType)
+		if !ok {
+			return getInvalidTypeAtLocationError(location+"."+field.FieldName, mapTypeString)
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return getNotSettableParamAtLocationError(location, name)
+		}
+
+		value := reflect.ValueOf(values[0])
+		if isSlice {
+			value = reflect.ValueOf(values)
+		}
+
+		getMapValue(field).SetMapIndex(reflect.ValueOf(subKey).Convert(mapType.Key()), value.Convert(elemType))
+		bound[name] = true
+		binder.report.addField(location, key, field.FieldName, false)
+	}
+
+	return nil
+}
+
+// Binds the bracketed keys of the struct fields with the `style=deepObject` option (`filter[name]=a&filter[age]=5`)
+// into the fields of the struct, maps are bound from the bracketed keys whatever their style is
+func (binder *Binder) setDeepObjectValues(location string, fields map[string]*structFieldData, params url.Values, bound map[string]bool) error {
+	objects := map[string]url.Values{}
+
+	for key, values := range params {
+		name, subKey, ok := parseBracketedKey(key)
+		if !ok {
+			continue
+		}
+
+		field, ok := fields[name]
+		if !ok || field.Options.Get(styleOption) != deepObjectStyle || isMapType(field.Value.Type()) {
+			continue
+		}
+
+		if !field.Value.CanSet() {
+			// The field is not settable, should return an error
+			return getNotSettableParamAtLocationError(location, name)
+		}
+
+		if objects[name] == nil {
+			objects[name] = url.Values{}
+		}
+
+		objects[name][subKey] = values
+		binder.report.addField(location, key, field.FieldName, false)
+	}
+
+	for name, object := range objects {
+		if err := binder.setNestedStructValues(location, fields[name], object); err != nil {
+			return err
+		}
+
+		bound[name] = true
+	}
+
+	return nil
+}
+
+// Splits the joined values of slice (and array) fields by the OpenAPI serialization style of the field: commas for
+// fields tagged with `explode:"false"` or `binder:"ids,style=form,explode=false"` (`?ids=1,2,3`), spaces for
+// `style=spaceDelimited` and pipes for `style=pipeDelimited`. Values of repeated keys are concatenated, the
+// elements are trimmed and empty elements are skipped.
+func splitUnexplodedValues(field *structFieldData, values []string) ([]string, error) {
+	if field.Value.Kind() != reflect.Slice && field.Value.Kind() != reflect.Array {
+		return values, nil
+	}
+
+	separator, err := getValuesSeparator(field)
+	if err != nil || separator == "" {
+		return values, err
+	}
+
+	elements := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, element := range strings.Split(value, separator) {
+			if element = strings.TrimSpace(element); element != "" {
+				elements = append(elements, element)
+			}
+		}
+	}
+
+	return elements, nil
+}
+
+// Returns the separator that joins the values of the field by its style, or an empty string if they aren't joined
+func getValuesSeparator(field *structFieldData) (string, error) {
+	explode := field.Tag.Get(explodeTag)
+	if field.Options.Has(explodeOption) {
+		explode = field.Options.Get(explodeOption)
+	}
+
+	switch style := field.Options.Get(styleOption); style {
+	case "", formStyle:
+		if explode == "false" {
+			return ",", nil
+		}
+
+		return "", nil
+	case spaceDelimitedStyle:
+		return " ", nil
+	case pipeDelimitedStyle:
+		return "|", nil
+	case deepObjectStyle:
+		return "", nil
+	default:
+		return "", internalServerError(getInvalidOptionValueError(field.location, field.FieldName, styleOption, style))
+	}
+}
+
+// Parses the value as a query string and binds it into the struct (or pointer to a struct) of the field
+func (binder *Binder) setNestedQueryValues(field *structFieldData, name, value string) error {
+	params, err := url.ParseQuery(value)
+	if err != nil {
+		return getMalformedParamAtLocationError(queryField, name, err)
+	}
+
+	return binder.setNestedStructValues(queryField, field, params)
+}
+
+// Binds the params into the fields of the struct (or pointer to a struct) of the field by their identifiers
+func (binder *Binder) setNestedStructValues(location string, field *structFieldData, params url.Values) error {
+	target := *field.Value
+	if target.Kind() == reflect.Ptr && target.Type().Elem().Kind() == reflect.Struct {
+		field.prepare()
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		target = target.Elem()
+	} else if target.Kind() != reflect.Struct {
+		return getInvalidTypeAtLocationError(location+"."+field.FieldName, structTypeString)
+	}
+
+	fields, err := binder.getNestedStructFields(location, &target)
+	if err != nil {
+		return err
+	}
+
+	field.prepare()
+	for nestedName, values := range params {
+		nestedField, ok := fields[nestedName]
+		if !ok {
+			// Didn't found a field to bound to this param, continue
+			continue
+		}
+
+		if err := binder.setFieldValues(nestedField, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decodes the value into the []byte (or *[]byte) target by the encoding of the `encoding` tag of the field, which is
+// either `base64` (the URL-safe alphabet, with or without padding) or `hex`, base64 being the default
+func setBytesValue(field *structFieldData, value string, target *reflect.Value) error {
+	var content []byte
+	var err error
+
+	switch encoding := field.Tag.Get(encodingTag); encoding {
+	case "", base64Encoding:
+		content, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	case hexEncoding:
+		content, err = hex.DecodeString(value)
+	default:
+		return internalServerError(getInvalidOptionValueError(field.location, field.FieldName, encodingTag, encoding))
 	}
-}
 
-func (binder *Binder) CallEchoDefaultBinderOnError(value bool) {
-	binder.callEchoDefaultBinderOnError = value
-}
+	if err != nil {
+		return getMalformedParamAtLocationError(field.location, field.identifier, err)
+	}
 
-func (binder *Binder) IgnoreNullStringOnHeader(value bool) {
-	binder.ignoreNullStringOnHeader = value
+	if target.Kind() == reflect.Ptr {
+		target.Set(reflect.ValueOf(&content))
+	} else {
+		target.SetBytes(content)
+	}
+
+	return nil
 }
 
-func (binder Binder) Bind(i interface{}, c echo.Context) error {
-	structType := reflect.TypeOf(i)
+// Splits the value on the separator of the `rangesep` option (`2023-01-01..2023-02-01`) and sets its ends into the
+// Start and End fields of the struct (or pointer to a struct) of the field, both of the ends must be sent.
+func (binder *Binder) setRangeValues(field *structFieldData, name, value string) error {
+	separator := field.Options.Get(rangeSeparatorOption)
+	if separator == "" {
+		return internalServerError(getInvalidOptionValueError(queryField, field.FieldName, rangeSeparatorOption, separator))
+	}
 
-	// Make sure that we get a structure to bind
-	if structType.Kind() != reflect.Ptr {
-		return badRequestError(errorInvalidType)
+	rangeType := field.Value.Type()
+	if rangeType.Kind() == reflect.Ptr {
+		rangeType = rangeType.Elem()
 	}
 
-	// Get the actual element instead of the pointer
-	structType = structType.Elem()
+	var startField, endField reflect.StructField
+	ok := rangeType.Kind() == reflect.Struct
+	if ok {
+		startField, ok = rangeType.FieldByName("Start")
+	}
 
-	// Check that the data is actually a struct
-	if structType.Kind() != reflect.Struct {
-		if binder.callEchoDefaultBinderOnError {
-			return binder.defaultBinder.Bind(i, c)
+	if ok {
+		endField, ok = rangeType.FieldByName("End")
+	}
+
+	if !ok || !startField.IsExported() || !endField.IsExported() {
+		return getInvalidTypeAtLocationError(queryField+"."+field.FieldName, rangeTypeString)
+	}
+
+	start, end, found := strings.Cut(value, separator)
+	if !found || start == "" || end == "" {
+		return getMalformedParamAtLocationError(queryField, name, getIncompleteRangeError(separator))
+	}
+
+	target := reflect.New(rangeType).Elem()
+	for _, bound := range []struct {
+		field reflect.StructField
+		value string
+	}{{startField, start}, {endField, end}} {
+		value := target.FieldByIndex(bound.field.Index)
+		if err := binder.setValue(field, bound.value, &value); err != nil {
+			return getMalformedParamAtLocationError(queryField, name, getInvalidRangeBoundError(bound.field.Name, err))
 		}
+	}
 
-		return badRequestError(errorInvalidType)
+	field.prepare()
+	if field.Value.Kind() == reflect.Ptr {
+		field.Value.Set(target.Addr())
+	} else {
+		field.Value.Set(target)
 	}
 
-	structValue := reflect.ValueOf(i).Elem()
+	return nil
+}
 
-	calledHandler := false
+// Returns the field that is tagged with the option, such as the `rest` field which captures the params that aren't
+// bound to other fields, or the `raw` field which captures all of them
+func getOptionField(fields map[string]*structFieldData, option string) *structFieldData {
+	for _, field := range fields {
+		if field.Options.Has(option) {
+			return field
+		}
+	}
 
-	// Iterate over all the fields of the structure and check for the path, query and body members
-	for i := 0; i < structType.NumField(); i++ {
-		typeField := structType.Field(i)
+	return nil
+}
 
-		// Find the handler for the field by its name
-		handler, ok := fieldHandlers[typeField.Name]
-		if !ok {
-			// Didn't found a handler for this field name, skip it
-			continue
+// Sets the params into the rest (or raw) field, which is either a map of strings or a map of slices of strings
+func setRestValues(location string, rest *structFieldData, params []string, values url.Values) error {
+	mapType := rest.Value.Type()
+	if mapType.Kind() == reflect.Ptr {
+		mapType = mapType.Elem()
+	}
+
+	isSlice, ok := getStringMapKind(mapType)
+	if !ok {
+		return getInvalidTypeAtLocationError(location+"."+rest.FieldName, mapTypeString)
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+
+	if !rest.Value.CanSet() {
+		// The field is not settable, should return an error
+		return getNotSettableParamAtLocationError(location, rest.FieldName)
+	}
+
+	target := getMapValue(rest)
+	for _, param := range params {
+		value := reflect.ValueOf(values[param][0])
+		if isSlice {
+			value = reflect.ValueOf(values[param])
 		}
 
-		kind := typeField.Type.Kind()
+		target.SetMapIndex(reflect.ValueOf(param).Convert(mapType.Key()), value.Convert(mapType.Elem()))
+	}
 
-		// If the kind is a pointer get the actual kind
-		if kind == reflect.Ptr {
-			kind = typeField.Type.Elem().Kind()
+	return nil
+}
+
+// Returns the params (sorted by name) that can't be bound into any of the fields, either directly,
+// as an element of an indexed struct slice or as a key of a map
+func getUnknownParams(fields map[string]*structFieldData, params url.Values) []string {
+	unknown := []string{}
+
+	for key := range params {
+		if field, ok := fields[key]; ok && !field.Options.Has(restOption) && !isMapType(field.Value.Type()) {
+			continue
 		}
 
-		// If the field is not a structure, return an error for that field
-		// Only if the field is not a body
-		if kind != reflect.Struct && typeField.Name != bodyField {
-			if binder.callEchoDefaultBinderOnError {
-				return binder.defaultBinder.Bind(i, c)
+		if name, _, _, ok := parseIndexedKey(key); ok {
+			if field, ok := fields[name]; ok && isStructSliceType(field.Value.Type()) {
+				continue
 			}
+		}
 
-			return badRequestError(getInvalidTypeAtLocationError(typeField.Name, structTypeString))
+		if name, _, ok := parseBracketedKey(key); ok {
+			if field, ok := fields[name]; ok && isMapType(field.Value.Type()) && !field.Options.Has(restOption) && !field.Options.Has(rawOption) {
+				continue
+			} else if ok && field.Options.Get(styleOption) == deepObjectStyle {
+				continue
+			}
 		}
 
-		// Get the structField of the field
-		structField := structValue.Field(i)
-		calledHandler = true
-		if err := handler(&binder, c, structType, &structValue, &structField); err != nil {
-			return badRequestError(err)
+		if _, ok := getGroupField(fields, key); ok {
+			continue
 		}
+
+		unknown = append(unknown, key)
 	}
 
-	if !calledHandler && binder.callEchoDefaultBinderOnError {
-		return binder.defaultBinder.Bind(i, c)
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Returns whether the type is a bool or a pointer to a bool
+func isBoolType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
 	}
 
-	if binder.validator != nil {
-		if err := binder.validator.Struct(i); err != nil {
-			return badRequestError(err)
-		}
+	return fieldType.Kind() == reflect.Bool
+}
+
+// Returns whether the boolean type (or the pointer to one) unmarshals itself, so its values are left to it
+func isBoolUnmarshalerType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
 	}
 
-	return nil
+	return isUnmarshalerType(fieldType)
 }
 
-type structFieldData struct {
-	FieldName string
-	Value     *reflect.Value
+// Parses the value as one of the accepted boolean values, ok is false when it isn't one of them
+func (binder *Binder) parseBool(value string) (boolVal bool, ok bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return false, true
+	}
+
+	boolVal, ok = binder.boolValues[value]
+	return boolVal, ok
 }
 
-var fieldHandlers = map[string]func(*Binder, echo.Context, reflect.Type, *reflect.Value, *reflect.Value) error{
-	pathField:   bindPath,
-	queryField:  bindQuery,
-	bodyField:   bindBody,
-	formField:   bindForm,
-	headerField: bindHeader,
+// Returns whether the type is an integer, an unsigned integer or a float (or a pointer to one of them)
+func isNumericType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
 }
 
-func bindPath(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
-	fields, err := getStructFields(structField)
-	if err != nil {
-		return badRequestError(err)
+// Returns whether the type is an unsigned integer or a pointer to one, types that unmarshal themselves are not
+func isUnsignedType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
 	}
 
-	names := c.ParamNames()
-	values := c.ParamValues()
+	if isUnmarshalerType(fieldType) {
+		return false
+	}
 
-	for i := 0; i < len(names); i++ {
-		name := names[i]
+	switch fieldType.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
 
-		field, ok := fields[name]
-		if !ok {
-			// Didn't found a field to bound to this path parameter, should return a bad request error.
-			return badRequestError(getMissingParamAtLocationError(pathField, name))
-		}
+	return false
+}
 
-		if !field.Value.CanSet() {
-			// The field is not settable, should return an error
-			return badRequestError(getNotSettableParamAtLocationError(pathField, name))
-		}
+// Removes the underscores that separate digits (`1_000_000`) the same way Go literals allow them, every underscore
+// must be between two digits, otherwise the value is returned as is so parsing it fails.
+func removeDigitSeparators(value string) string {
+	if !strings.Contains(value, "_") {
+		return value
+	}
 
-		if err := setWithProperType(field.Value.Kind(), values[i], field.Value); err != nil {
-			return badRequestError(err)
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '_' && (i == 0 || i == len(value)-1 || !isDigit(value[i-1]) || !isDigit(value[i+1])) {
+			return value
 		}
 	}
 
-	return nil
+	return strings.ReplaceAll(value, "_", "")
 }
 
-func bindQuery(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
-	// Check if the method is valid for the query binding
-	method := c.Request().Method
-	if method != http.MethodGet && method != http.MethodDelete && method != http.MethodHead {
-		return badRequestError(getUnsupportedHttpMethodError(queryField, method))
+// Removes a single pair of double quotes that surround the value (`"Omri"`) of over-quoting clients, the escape
+// sequences inside of them are unescaped when they are valid (`"a\"b"` is `a"b`) and kept as is otherwise.
+func unquoteValue(value string) string {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
 	}
 
-	fields, err := getStructFields(structField)
-	if err != nil {
-		return badRequestError(getInvalidAnonymousFieldError(pathField))
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
 	}
 
-	params := c.QueryParams()
+	return value[1 : len(value)-1]
+}
 
-	for name, values := range params {
-		field, ok := fields[name]
-		if !ok {
-			// Didn't found a field to bound to this query parameter, continue
+// Returns whether the type is a map or a pointer to a map
+func isMapType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	return fieldType.Kind() == reflect.Map
+}
+
+// Returns whether the map type can be bound, which is a map of string keys to either strings or slices of strings,
+// and whether its values are slices
+func getStringMapKind(mapType reflect.Type) (bool, bool) {
+	if mapType.Kind() != reflect.Map || mapType.Key().Kind() != reflect.String {
+		return false, false
+	}
+
+	elemType := mapType.Elem()
+	isSlice := elemType.Kind() == reflect.Slice && elemType.Elem().Kind() == reflect.String
+	return isSlice, isSlice || elemType.Kind() == reflect.String
+}
+
+// Binds params whose keys start with the prefix of a field tagged with the `group` option (`?tag.a=1&tag.b=2` into
+// `binder:"tag.,group"`) into the map of the field, keyed by the rest of the key.
+func (binder *Binder) setGroupValues(location string, fields map[string]*structFieldData, params url.Values, bound map[string]bool) error {
+	for prefix, field := range fields {
+		if !field.Options.Has(groupOption) {
 			continue
 		}
 
-		if !field.Value.CanSet() {
-			// The field is not settable, should return an error
-			return badRequestError(getNotSettableParamAtLocationError(queryField, name))
+		mapType := field.Value.Type()
+		if mapType.Kind() == reflect.Ptr {
+			mapType = mapType.Elem()
 		}
 
-		switch field.Value.Type().Kind() {
-		case reflect.Slice:
-			// sliceKind := field.StructField.Type.Elem().Kind()
-			sliceKind := field.Value.Type().Elem().Kind()
-			slice := reflect.MakeSlice(field.Value.Type(), len(values), len(values))
+		isSlice, ok := getStringMapKind(mapType)
+		if !ok {
+			return getInvalidTypeAtLocationError(location+"."+field.FieldName, mapTypeString)
+		}
 
-			// Build the slice with the values
-			for i := 0; i < len(values); i++ {
-				value := slice.Index(i)
-				if err := setWithProperType(sliceKind, values[i], &value); err != nil {
-					return badRequestError(err)
-				}
+		for key, values := range params {
+			if len(key) <= len(prefix) || !strings.HasPrefix(key, prefix) {
+				continue
 			}
 
-			// Set the slice to the field
-			field.Value.Set(slice)
+			if !field.Value.CanSet() {
+				// The field is not settable, should return an error
+				return getNotSettableParamAtLocationError(location, prefix)
+			}
 
-		default:
-			if err := setWithProperType(field.Value.Kind(), values[0], field.Value); err != nil {
-				return badRequestError(err)
+			value := reflect.ValueOf(values[0])
+			if isSlice {
+				value = reflect.ValueOf(values)
 			}
+
+			getMapValue(field).SetMapIndex(reflect.ValueOf(key[len(prefix):]).Convert(mapType.Key()), value.Convert(mapType.Elem()))
+			bound[prefix] = true
+			binder.report.addField(location, key, field.FieldName, false)
 		}
 	}
 
 	return nil
 }
 
-func bindBody(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) (err error) {
-	request := c.Request()
-
-	// Check if the method is valid for body binding and if there is content in the body
-	if request.Method == http.MethodGet {
-		return badRequestError(getUnsupportedHttpMethodError(bodyField, request.Method))
-	} else if request.ContentLength == 0 {
-		return nil
+// Returns the field of the group (by the prefix of the key) that the param is bound into
+func getGroupField(fields map[string]*structFieldData, key string) (*structFieldData, bool) {
+	for prefix, field := range fields {
+		if field.Options.Has(groupOption) && len(key) > len(prefix) && strings.HasPrefix(key, prefix) {
+			return field, true
+		}
 	}
 
-	// Check if the content type is valid for body binding
-	contentType := request.Header.Get(echo.HeaderContentType)
+	return nil, false
+}
 
-	body, err := ioutil.ReadAll(c.Request().Body)
-	if err != nil {
-		return internalServerError(err)
-	}
+// Returns the map of a map field, allocating the map (and the pointer to it) if it's nil
+func getMapValue(field *structFieldData) reflect.Value {
+	field.prepare()
 
-	switch {
-	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
-		if err := json.Unmarshal(body, structField.Addr().Interface()); err != nil {
-			return badRequestError(err)
+	target := *field.Value
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
 		}
 
-	case strings.HasPrefix(contentType, echo.MIMEApplicationXML), strings.HasPrefix(contentType, echo.MIMETextXML):
-		if err := xml.Unmarshal(body, structField.Addr().Interface()); err != nil {
-			return badRequestError(err)
-		}
+		target = target.Elem()
 	}
 
-	if structField.Type().Kind() != reflect.Struct {
-		// If the body is not a struct, no need to fill the BodySentFields field.
-		return nil
+	if target.IsNil() {
+		target.Set(reflect.MakeMap(target.Type()))
 	}
 
-	field, found := structType.FieldByName(bodySentFields)
-	if !found {
-		// Didn't found the body sent field, so we just don't bind it.
-		return nil
-	} else if field.Type.Kind() != reflect.TypeOf(RecursiveLookupTable{}).Kind() {
-		return badRequestError(getInvalidTypeAtLocationError(bodySentFields, lookupTypeString))
+	return target
+}
+
+// Splits a bracketed key of the form `name[subKey]` into its parts
+func parseBracketedKey(key string) (string, string, bool) {
+	start := strings.IndexByte(key, '[')
+	if start <= 0 || !strings.HasSuffix(key, "]") {
+		return "", "", false
 	}
 
-	fieldValue := structValue.FieldByName(bodySentFields)
-	if !fieldValue.CanSet() {
-		return badRequestError(getNotSettableParamAtLocationError(structValue.Type().Name(), bodySentFields))
+	return key[:start], key[start+1 : len(key)-1], true
+}
+
+// Splits an indexed key of the form `name[index].subKey` into its parts
+func parseIndexedKey(key string) (string, int, string, bool) {
+	start := strings.IndexByte(key, '[')
+	end := strings.IndexByte(key, ']')
+	if start <= 0 || end < start || !strings.HasPrefix(key[end+1:], ".") {
+		return "", 0, "", false
 	}
 
-	data := lookupTable{}
-	if err := json.Unmarshal(body, &data); err != nil {
-		return badRequestError(err)
+	index, err := strconv.Atoi(key[start+1 : end])
+	if err != nil || index < 0 {
+		return "", 0, "", false
 	}
 
-	fieldValue.Set(reflect.ValueOf(data.IntoRecursiveLookupTable()))
-	return nil
+	return key[:start], index, key[end+2:], true
 }
 
-func bindForm(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
-	request := c.Request()
-
-	// Check if the method is valid for body binding and if there is content in the body
-	if request.Method == http.MethodGet {
-		return badRequestError(getUnsupportedHttpMethodError(bodyField, request.Method))
-	} else if request.ContentLength == 0 {
-		return nil
+// Returns whether the type is a slice whose elements are structs (or pointers to structs) that should be walked
+func isStructSliceType(fieldType reflect.Type) bool {
+	if fieldType.Kind() != reflect.Slice {
+		return false
 	}
 
-	// Check if the content type is valid for form binding
-	contentType := request.Header.Get(echo.HeaderContentType)
-	if !strings.HasPrefix(contentType, echo.MIMEApplicationForm) && !strings.HasPrefix(contentType, echo.MIMEMultipartForm) {
-		return nil
+	elemType := fieldType.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
 	}
 
-	fields, err := getStructFields(structField)
-	if err != nil {
-		return badRequestError(getInvalidAnonymousFieldError(formField))
-	}
+	return elemType.Kind() == reflect.Struct && !isLeafType(elemType, tagOptions{}) && !isUnmarshalerType(elemType)
+}
 
-	values, err := c.FormParams()
-	if err != nil {
-		return badRequestError(err)
+// Returns the default of the field at location, which is computed by the function registered by SetDefaultFunc or
+// taken from the `default` tag, ok is false when the field has no default.
+func (binder *Binder) getDefaultValue(c echo.Context, location, name string, field *structFieldData) (value string, ok bool, err error) {
+	if defaultFunc, ok := binder.defaultFuncs[location+"."+name]; ok {
+		if value, err = defaultFunc(c); err != nil {
+			return "", false, internalServerError(err)
+		}
+
+		return value, true, nil
 	}
 
-	for name, values := range values {
-		field, ok := fields[name]
-		if !ok {
-			// Didn't found a field to bound to this form parameter, continue
+	value, ok = field.Tag.Lookup(defaultTag)
+	return value, ok, nil
+}
+
+// Sets the default value into every field that has one and wasn't bound from the request.
+// This happens during the section binding, so the validation that runs after it sees the default values.
+func (binder *Binder) setDefaultValues(c echo.Context, location string, fields map[string]*structFieldData, bound map[string]bool) error {
+	for name, field := range fields {
+		if bound[name] || field.detached() {
+			// Defaults don't allocate nil pointer structures on their own, so they stay nil when nothing was sent
+			continue
+		}
+
+		defaultValue, ok, err := binder.getDefaultValue(c, location, name, field)
+		if err != nil {
+			return err
+		} else if !ok {
 			continue
 		}
 
 		if !field.Value.CanSet() {
 			// The field is not settable, should return an error
-			return badRequestError(getNotSettableParamAtLocationError(formField, name))
+			return getNotSettableParamAtLocationError(location, name)
 		}
 
-		switch field.Value.Type().Kind() {
-		case reflect.Slice:
-			sliceKind := field.Value.Type().Elem().Kind()
-			slice := reflect.MakeSlice(field.Value.Type(), len(values), len(values))
-
-			// Build the slice with the values
-			for i := 0; i < len(values); i++ {
-				value := slice.Index(i)
-				if err := setWithProperType(sliceKind, values[i], &value); err != nil {
-					return badRequestError(err)
-				}
-			}
-
-			// Set the slice to the field
-			field.Value.Set(slice)
-
-		default:
-			if err := setWithProperType(field.Value.Kind(), values[0], field.Value); err != nil {
-				return badRequestError(err)
-			}
+		if err := binder.setFieldValues(field, []string{defaultValue}); err != nil {
+			return err
 		}
+
+		binder.report.addField(location, name, field.FieldName, true)
 	}
 
 	return nil
 }
 
-func bindHeader(binder *Binder, c echo.Context, structType reflect.Type, structValue *reflect.Value, structField *reflect.Value) error {
-	fields, err := getStructFields(structField)
-	if err != nil {
-		return badRequestError(getInvalidAnonymousFieldError(headerField))
+// Returns whether there is a converter for the type (or the type it points to)
+func (binder *Binder) hasConverter(valueType reflect.Type) bool {
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
 	}
 
-	header := c.Request().Header
+	_, ok := binder.converters[valueType]
+	return ok
+}
 
-	for name, field := range fields {
-		headerValue := header.Get(name)
-		if headerValue == "" || (binder.ignoreNullStringOnHeader && headerValue == "null") {
-			continue
-		}
+// Converts the value with the converter that is registered for the type (or the type it points to), the second
+// return value reports whether there is such a converter
+func (binder *Binder) convertValue(value string, valueType reflect.Type) (reflect.Value, bool, error) {
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
 
-		if !field.Value.CanSet() {
-			// The field is not settable, should return an error
-			return badRequestError(getNotSettableParamAtLocationError(headerField, field.FieldName))
-		}
+	converter, ok := binder.converters[valueType]
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
 
-		if err := setWithProperType(field.Value.Kind(), headerValue, field.Value); err != nil {
-			return badRequestError(err)
-		}
+	converted, err := converter(value)
+	if err != nil {
+		return reflect.Value{}, true, err
 	}
 
-	return nil
-}
+	// Converters of interface types act as factories, they may return any type that implements the interface
+	convertedValue := reflect.ValueOf(converted)
+	if !convertedValue.IsValid() || (convertedValue.Type() != valueType && !(valueType.Kind() == reflect.Interface && convertedValue.Type().Implements(valueType))) {
+		// The converter itself is broken, so it's not the fault of the client
+		return reflect.Value{}, true, internalServerError(getInvalidConverterResultError(valueType, converted))
+	}
 
-// Returns a map of string to reflect.StructField out of a reflect.Value
-// This function assumes that the reflect.Value is a struct, and it will panic if it is not
-func getStructFields(structField *reflect.Value) (map[string]*structFieldData, error) {
-	fields := make(map[string]*structFieldData)
+	return convertedValue, true, nil
+}
 
-	for i := 0; i < structField.Type().NumField(); i++ {
-		fieldType := structField.Type().Field(i)
-		fieldStruct := structField.Field(i)
+// Sets a single value into target, which is either the field itself or one of its elements
+func (binder *Binder) setValue(field *structFieldData, value string, target *reflect.Value) error {
+	if field.Options.Has(unquoteOption) {
+		value = unquoteValue(value)
+	}
 
-		// If the field is an anonymous field, we need to get the fields of the struct it points to
-		if fieldType.Anonymous {
-			kind := fieldType.Type.Kind()
+	if converted, ok, err := binder.convertValue(value, target.Type()); ok {
+		if _, ok := err.(*echo.HTTPError); ok {
+			return err
+		} else if err != nil {
+			return getConversionAtLocationError(field.location, field.FieldName, err)
+		}
 
-			// If the kind is a pointer let's get the real kind
-			if kind == reflect.Ptr {
-				kind = fieldType.Type.Elem().Kind()
+		if target.Kind() == reflect.Ptr {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
 			}
 
-			// If its not a struct, we can't get the fields of it
-			if kind != reflect.Struct {
-				return nil, errorInvalidAnonymousField
-			}
+			target.Elem().Set(converted)
+			return nil
 		}
 
-		kind := fieldType.Type.Kind()
-		isPointer := false
+		target.Set(converted)
+		return nil
+	}
+
+	switch target.Type() {
+	case fileBytesType, reflect.PtrTo(fileBytesType):
+		return setBytesValue(field, value, target)
 
-		// If the kind is a pointer let's get the real kind
-		if kind == reflect.Ptr {
-			kind = fieldType.Type.Elem().Kind()
-			isPointer = true
+	case timeType, reflect.PtrTo(timeType):
+		layout := field.Tag.Get(timeFormatTag)
+		if layout == "" {
+			layout = binder.defaultTimeFormat
 		}
 
-		// If the kind is a struct, let's get the fields of it.
-		if kind == reflect.Struct {
-			if isPointer && fieldStruct.IsNil() {
-				fieldStruct.Set(reflect.New(fieldType.Type.Elem()))
-				fieldStruct = fieldStruct.Elem()
-			}
-
-			tempFields, err := getStructFields(&fieldStruct)
-			if err != nil {
-				return nil, err
+		err := setTimeField(value, layout, target)
+		if err != nil && field.location == formField {
+			// Forms are mostly sent by browsers, so the formats of the HTML date and time inputs are accepted as well
+			for _, htmlLayout := range htmlTimeLayouts {
+				if setTimeField(value, htmlLayout, target) == nil {
+					return nil
+				}
 			}
+		}
 
-			for name, field := range tempFields {
-				fields[name] = field
-			}
+		return err
+	}
 
-			continue
+	if field.Options.Has(intboolOption) && isBoolType(target.Type()) {
+		// Lenient clients send any non-zero integer as true
+		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
+			value = strconv.FormatBool(intVal != 0)
 		}
+	}
 
-		identifier := fieldType.Tag.Get(TagIdentifier)
-		if identifier == "" {
-			identifier = fieldType.Name
-		} else if identifier == "-" {
-			// Make sure we don't add this field
-			continue
+	if isBoolType(target.Type()) && !isBoolUnmarshalerType(target.Type()) {
+		boolVal, ok := binder.parseBool(value)
+		if !ok {
+			return getInvalidBoolAtLocationError(field.location, field.identifier, value)
 		}
 
-		fields[identifier] = &structFieldData{FieldName: fieldType.Name, Value: &fieldStruct}
+		value = strconv.FormatBool(boolVal)
 	}
 
-	return fields, nil
+	if field.Options.Has(underscoresOption) && isNumericType(target.Type()) {
+		value = removeDigitSeparators(value)
+	}
+
+	if isUnsignedType(target.Type()) && strings.HasPrefix(value, "-") {
+		// strconv.ParseUint reports negative values as a plain syntax error
+		return getNegativeValueAtLocationError(field.location, field.identifier, value)
+	}
+
+	if target.Kind() == reflect.Interface && binder.inferScalarTypes && !holdsUnmarshaler(target) {
+		return setInferredScalarField(value, target)
+	}
+
+	return setWithProperType(target.Kind(), value, target)
 }
@@ -1,11 +1,38 @@
 package echo_binder
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unsafe"
 
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,6 +43,69 @@ type validEmbedded struct {
 	Unbind string `binder:"-"`
 }
 
+type skippedNested struct {
+	City string `binder:"city"`
+}
+
+type skippedFieldsTester struct {
+	Path struct {
+		Id     int    `binder:"id"`
+		Tenant string `binder:"-"`
+	}
+
+	Query struct {
+		Name    string        `binder:"name"`
+		Secret  string        `binder:"-"`
+		Address skippedNested `binder:"-"`
+	}
+
+	Form struct {
+		Role string `binder:"-"`
+	}
+
+	Header struct {
+		Token string `binder:"-"`
+	}
+}
+
+func TestSkippedFieldsBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	form := url.Values{"Role": {"admin"}, "-": {"admin"}}
+	req := httptest.NewRequest(http.MethodPost, "/tenants/acme/users/1?name=Omri&Secret=x&-=x&city=Haifa&Address=x", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Token", "token")
+	req.Header.Set("-", "token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// The path param of the skipped field is not a missing field, and it isn't bound either
+	c.SetParamNames("Tenant", "id")
+	c.SetParamValues("acme", "1")
+
+	data := skippedFieldsTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(1, data.Path.Id)
+		assert.Empty(data.Path.Tenant)
+		assert.Equal("Omri", data.Query.Name)
+		assert.Empty(data.Query.Secret)
+		assert.Empty(data.Query.Address.City)
+		assert.Empty(data.Form.Role)
+		assert.Empty(data.Header.Token)
+	}
+
+	// Other params without a field are still missing
+	c.SetParamNames("tenant", "id")
+	err := c.Bind(&skippedFieldsTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "missing param `tenant` at `Path`")
+	}
+}
+
 type allTypes struct {
 	Bool      bool     `binder:"bool"`
 	Int       int      `binder:"int"`
@@ -117,6 +207,79 @@ func TestBinderErrors(t *testing.T) {
 	invalid2.Path.invalidEmbedded.string = ""
 	err = c.Bind(&invalid2)
 	assert.Error(err)
+
+	// Errors of the sections are returned as is, without being wrapped again
+	type missingParam struct {
+		Path struct {
+			Name string
+		}
+	}
+
+	err = c.Bind(new(missingParam))
+	httpError := new(echo.HTTPError)
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusBadRequest, httpError.Code)
+		assert.Equal("missing param `Id` at `Path`", httpError.Message)
+	}
+
+	// Errors that are caused by the server configuration keep their status
+	type unsignedHeader struct {
+		Header struct {
+			Signature string `binder:"X-Signature,hmac"`
+		}
+	}
+
+	err = c.Bind(new(unsignedHeader))
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusInternalServerError, httpError.Code)
+	}
+}
+
+func TestBindType(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// The type to bind is resolved at runtime by the route
+	routes := map[string]reflect.Type{
+		"/users/:Name/:Id": reflect.TypeOf(pathNormalTester{}),
+		"/users":           reflect.TypeOf(queryTester{}),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/users/:Name/:Id")
+	c.SetParamNames("Name", "Id")
+	c.SetParamValues("Omri Siniver", "3")
+
+	result, err := binder.BindType(routes[c.Path()], c)
+	if assert.NoError(err) && assert.IsType(new(pathNormalTester), result) {
+		assert.Equal("Omri Siniver", result.(*pathNormalTester).Path.Name)
+		assert.Equal(3, result.(*pathNormalTester).Path.Id)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?Name=Omri&Age=3.5", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetPath("/users")
+
+	result, err = binder.BindType(routes[c.Path()], c)
+	if assert.NoError(err) && assert.IsType(new(queryTester), result) {
+		assert.Equal("Omri", result.(*queryTester).Query.Name)
+		assert.Equal(3.5, result.(*queryTester).Query.Age)
+	}
+
+	// Types that can't be bound return the same errors as Bind
+	result, err = binder.BindType(reflect.TypeOf(0), c)
+	assert.Error(err)
+	assert.Nil(result)
+
+	result, err = binder.BindType(nil, c)
+	assert.Error(err)
+	assert.Nil(result)
 }
 
 type unhandledStructsTester struct {
@@ -160,6 +323,198 @@ func TestBinderUnhandledStructs(t *testing.T) {
 	}
 }
 
+type pathRequiredTester struct {
+	Path struct {
+		Id   string `binder:"id,required"`
+		Slug string `binder:"slug"`
+	}
+}
+
+func TestPathRequiredBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	newContext := func(names []string, values []string) echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames(names...)
+		c.SetParamValues(values...)
+		return c
+	}
+
+	data := pathRequiredTester{}
+	if assert.NoError(newContext([]string{"id", "slug"}, []string{"7", ""}).Bind(&data)) {
+		assert.Equal("7", data.Path.Id)
+		assert.Empty(data.Path.Slug)
+	}
+
+	// Empty and undeclared required params fail before the validation
+	for _, c := range []echo.Context{newContext([]string{"id", "slug"}, []string{"", "post"}), newContext([]string{"slug"}, []string{"post"})} {
+		err := c.Bind(&pathRequiredTester{})
+		if assert.Error(err) {
+			assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+			assert.Contains(err.Error(), "required param `id` is missing at `Path`")
+		}
+	}
+}
+
+type pathCatchAllTester struct {
+	Path struct {
+		Segments []string `binder:"*"`
+	}
+}
+
+type pathCatchAllRawTester struct {
+	Path struct {
+		File string `binder:"*,raw"`
+	}
+}
+
+type pathCatchAllRawSliceTester struct {
+	Path struct {
+		Files []string `binder:"*,raw"`
+	}
+}
+
+type pathCatchAllStringTester struct {
+	Path struct {
+		Id   string `binder:"id"`
+		Tail string `binder:"*"`
+	}
+}
+
+func TestPathCatchAllBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	var (
+		segments pathCatchAllTester
+		raw      pathCatchAllRawTester
+		rawSlice pathCatchAllRawSliceTester
+	)
+
+	e.GET("/files/*", func(c echo.Context) error {
+		segments, raw, rawSlice = pathCatchAllTester{}, pathCatchAllRawTester{}, pathCatchAllRawSliceTester{}
+		if err := c.Bind(&segments); err != nil {
+			return err
+		}
+
+		if err := c.Bind(&raw); err != nil {
+			return err
+		}
+
+		return c.Bind(&rawSlice)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/files/a/b//c.txt", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if assert.Equal(http.StatusOK, rec.Code) {
+		assert.Equal([]string{"a", "b", "c.txt"}, segments.Path.Segments)
+		assert.Equal("a/b//c.txt", raw.Path.File)
+		assert.Equal([]string{"a/b//c.txt"}, rawSlice.Path.Files)
+	}
+
+	// An empty remainder leaves the slices nil
+	req = httptest.NewRequest(http.MethodGet, "/files/", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if assert.Equal(http.StatusOK, rec.Code) {
+		assert.Nil(segments.Path.Segments)
+		assert.Empty(raw.Path.File)
+		assert.Nil(rawSlice.Path.Files)
+	}
+
+	// String fields get the whole remainder, and routes without a wildcard leave them empty
+	var tail pathCatchAllStringTester
+	handler := func(c echo.Context) error {
+		tail = pathCatchAllStringTester{}
+		return c.Bind(&tail)
+	}
+
+	// Routes with more params need a new echo, since the contexts of the pool only fit the params of the old routes
+	e = echo.New()
+	e.Binder = New()
+	e.GET("/users/:id/*", handler)
+	e.GET("/teams/:id", handler)
+
+	req = httptest.NewRequest(http.MethodGet, "/users/5/posts/7", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if assert.Equal(http.StatusOK, rec.Code) {
+		assert.Equal("5", tail.Path.Id)
+		assert.Equal("posts/7", tail.Path.Tail)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/teams/3", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if assert.Equal(http.StatusOK, rec.Code) {
+		assert.Equal("3", tail.Path.Id)
+		assert.Empty(tail.Path.Tail)
+	}
+}
+
+type pathMapTester struct {
+	Path map[string]string
+
+	Query struct {
+		Verbose bool `binder:"verbose"`
+	}
+}
+
+type pathMapPointerTester struct {
+	Path *map[string]string
+}
+
+func TestPathMapBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/tenants/acme/users/5?verbose=true", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("tenant", "id", "*")
+	c.SetParamValues("acme", "5", "posts/7")
+
+	// All of the params of the route land in the map by their names, next to the other sections
+	data := pathMapTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(map[string]string{"tenant": "acme", "id": "5", "*": "posts/7"}, data.Path)
+		assert.True(data.Query.Verbose)
+	}
+
+	pointer := pathMapPointerTester{}
+	if assert.NoError(c.Bind(&pointer)) && assert.NotNil(pointer.Path) {
+		assert.Equal(map[string]string{"tenant": "acme", "id": "5", "*": "posts/7"}, *pointer.Path)
+	}
+
+	assert.NoError(binder.ValidateSchema(&pathMapTester{}))
+
+	// Routes without params leave the map nil
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/users", nil), httptest.NewRecorder())
+
+	data = pathMapTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Nil(data.Path)
+	}
+
+	// Only maps of strings can hold the params
+	assert.Error(c.Bind(&struct{ Path map[string]int }{}))
+}
+
 func TestPathBinder(t *testing.T) {
 	assert := assert.New(t)
 	e := echo.New()
@@ -300,6 +655,115 @@ func TestBodyBinder(t *testing.T) {
 	}
 }
 
+type bodyCodecPart struct {
+	Name string `json:"name" xml:"name"`
+}
+
+type bodyCodecTester struct {
+	Body struct {
+		JSONPart *bodyCodecPart `binder:",json"`
+		XMLPart  *bodyCodecPart `binder:",xml"`
+	}
+}
+
+func TestBodyCodecFieldBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// A JSON request fills only the JSON part
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"Omri"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(bodyCodecTester)
+	err := c.Bind(u)
+	if assert.NoError(err) && assert.NotNil(u.Body.JSONPart) {
+		assert.Equal("Omri", u.Body.JSONPart.Name)
+		assert.Nil(u.Body.XMLPart)
+	}
+
+	// An XML request fills only the XML part
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`<request><name>Koren</name></request>`))
+	req.Header.Set("Content-Type", "application/xml")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	u = new(bodyCodecTester)
+	err = c.Bind(u)
+	if assert.NoError(err) && assert.NotNil(u.Body.XMLPart) {
+		assert.Equal("Koren", u.Body.XMLPart.Name)
+		assert.Nil(u.Body.JSONPart)
+	}
+}
+
+// Stands for a generated protobuf message, which is decoded by the registered decoder
+type protoMessage struct {
+	Name string
+	Id   int
+}
+
+func (message *protoMessage) Unmarshal(data []byte) error {
+	name, id, found := strings.Cut(string(data), ":")
+	if !found {
+		return errors.New("invalid message")
+	}
+
+	parsed, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	message.Name, message.Id = name, parsed
+	return nil
+}
+
+type bodyProtoTester struct {
+	Body protoMessage
+
+	BodySentFields RecursiveLookupTable
+}
+
+func TestBodyDecoderBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.RegisterBodyDecoder("application/x-protobuf", func(body []byte, i interface{}) error {
+		message, ok := i.(interface{ Unmarshal([]byte) error })
+		if !ok {
+			return errors.New("body must be a message")
+		}
+
+		return message.Unmarshal(body)
+	})
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("Omri:3"))
+	req.Header.Set("Content-Type", "application/x-protobuf; proto=protoMessage")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(bodyProtoTester)
+	err := c.Bind(u)
+	if assert.NoError(err) {
+		assert.Equal(protoMessage{Name: "Omri", Id: 3}, u.Body)
+		assert.Nil(u.BodySentFields)
+	}
+
+	// Decoding errors fail the binding
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("Omri"))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err = c.Bind(new(bodyProtoTester))
+	assert.Error(err)
+}
+
 type queryTester struct {
 	Query struct {
 		Name      string
@@ -435,229 +899,4864 @@ func TestQueryBinder(t *testing.T) {
 	}
 }
 
-func getReference[T any](data T) *T {
-	return &data
-}
-
-type embeddedHeader struct {
-	Omer string `binder:"harari"`
-}
-
-type AnotherEmbeddedHeader struct {
-	Yaeli string `binder:"yerushalmi"`
-}
-
-type headerTester struct {
-	Header struct {
-		embeddedHeader
-		*AnotherEmbeddedHeader
-		Name  string
-		Build int `binder:"custom"`
-	}
+type queryAddress struct {
+	City string `binder:"city"`
+	Zip  string `binder:"zip"`
 }
 
-type headerEmbbeddedFieldTester struct {
-	Header struct {
-		string
+type queryOptionalObjectTester struct {
+	Query struct {
+		Name    string `binder:"name"`
+		Address *queryAddress
 	}
 }
 
-func TestHeaderBinder(t *testing.T) {
+func TestQueryOptionalNestedObject(t *testing.T) {
 	assert := assert.New(t)
 
 	e := echo.New()
 	binder := New()
-	binder.CallEchoDefaultBinderOnError(true)
 	e.Binder = binder
 
-	// Test normal header binding
-	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	// The nested object is not sent, so it should stay nil
+	req := httptest.NewRequest(http.MethodGet, "/users?name=Omri", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	c.Request().Header.Set("Name", "Omri")
-	c.Request().Header.Set("Custom", "132")
-	c.Request().Header.Set("harari", "1234")
-	c.Request().Header.Set("yerushalmi", "0525381648")
 
-	normal := new(headerTester)
-	err := c.Bind(normal)
+	unsent := new(queryOptionalObjectTester)
+	err := c.Bind(unsent)
 	if assert.NoError(err) {
-		assert.Equal("Omri", normal.Header.Name)
-		assert.Equal(132, normal.Header.Build)
-		assert.Equal("1234", normal.Header.embeddedHeader.Omer)
-		assert.Equal("0525381648", normal.Header.AnotherEmbeddedHeader.Yaeli)
+		assert.Equal("Omri", unsent.Query.Name)
+		assert.Nil(unsent.Query.Address)
 	}
 
-	// Test invalid embedded type binding
-	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	// One of the nested fields is sent, so the object should be allocated
+	req = httptest.NewRequest(http.MethodGet, "/users?name=Omri&city=Haifa", nil)
 	rec = httptest.NewRecorder()
 	c = e.NewContext(req, rec)
 
-	embedded := new(headerEmbbeddedFieldTester)
-	err = c.Bind(embedded)
+	sent := new(queryOptionalObjectTester)
+	err = c.Bind(sent)
+	if assert.NoError(err) && assert.NotNil(sent.Query.Address) {
+		assert.Equal("Haifa", sent.Query.Address.City)
+		assert.Equal("", sent.Query.Address.Zip)
+	}
+}
+
+type queryTimeTester struct {
+	Query struct {
+		Since  time.Time   `binder:"since"`
+		Until  *time.Time  `binder:"until"`
+		Dates  []time.Time `binder:"dates"`
+		Tagged time.Time   `binder:"tagged" time_format:"02/01/2006"`
+	}
+}
+
+func TestQueryTimeBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// RFC3339 is used by default
+	req := httptest.NewRequest(http.MethodGet, "/users?since=2023-01-02T15:04:05Z&tagged=03/01/2023", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	normal := new(queryTimeTester)
+	err := c.Bind(normal)
+	if assert.NoError(err) {
+		assert.Equal(time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), normal.Query.Since)
+		assert.Equal(time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC), normal.Query.Tagged)
+		assert.Nil(normal.Query.Until)
+	}
+
+	// The default time format applies to every field without a tag
+	binder.SetDefaultTimeFormat("2006-01-02")
+	req = httptest.NewRequest(http.MethodGet, "/users?since=2023-01-02&until=2023-02-01&dates=2023-03-01&dates=2023-03-02&tagged=03/01/2023", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	custom := new(queryTimeTester)
+	err = c.Bind(custom)
+	if assert.NoError(err) {
+		assert.Equal(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), custom.Query.Since)
+		assert.Equal(getReference(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)), custom.Query.Until)
+		assert.Equal([]time.Time{time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)}, custom.Query.Dates)
+		assert.Equal(time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC), custom.Query.Tagged)
+	}
+
+	// Values that don't match the default time format should fail
+	req = httptest.NewRequest(http.MethodGet, "/users?since=2023-01-02T15:04:05Z", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err = c.Bind(new(queryTimeTester))
+	assert.Error(err)
+}
+
+type lazyState struct {
+	once  sync.Once
+	cache map[string]string
+	Name  string `binder:"name"`
+}
+
+type queryUnexportedTester struct {
+	Query struct {
+		lazyState
+		Id     int    `binder:"id"`
+		secret string `binder:"secret"`
+		mutex  sync.Mutex
+	}
+}
+
+func TestQueryUnexportedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// Params that match unexported fields (including the internals of sync.Once) are ignored
+	req := httptest.NewRequest(http.MethodGet, "/users?name=Omri&id=3&secret=leaked&cache=1&done=1&m=1&state=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(queryUnexportedTester)
+	err := c.Bind(u)
+	if assert.NoError(err) {
+		assert.Equal("Omri", u.Query.Name)
+		assert.Equal(3, u.Query.Id)
+		assert.Equal("", u.Query.secret)
+		assert.Nil(u.Query.cache)
+	}
+}
+
+func TestUnsupportedKindsBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	type unsafePointerTester struct {
+		Query struct{ Value unsafe.Pointer }
+	}
+
+	targets := map[string]interface{}{
+		"chan":           new(struct{ Query struct{ Value chan int } }),
+		"func":           new(struct{ Query struct{ Value func() } }),
+		"unsafe.Pointer": new(unsafePointerTester),
+		"complex64":      new(struct{ Query struct{ Value []complex64 } }),
+		"complex128":     new(struct{ Query struct{ Value *complex128 } }),
+	}
+
+	for kind, target := range targets {
+		// The field is rejected even when no param is sent for it
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := c.Bind(target)
+		if assert.Error(err, kind) {
+			assert.Contains(err.Error(), "field `Value` of kind "+kind+" cannot be bound", kind)
+		}
+	}
+
+	// Ignored fields of unsupported kinds are fine
+	req := httptest.NewRequest(http.MethodGet, "/users?Value=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := c.Bind(new(struct {
+		Query struct {
+			Value    chan int `binder:"-"`
+			Callback func()   `binder:"-"`
+		}
+	}))
+	assert.NoError(err)
+}
+
+type queryUser struct {
+	Name string `binder:"name"`
+	Age  int    `binder:"age"`
+}
+
+type queryIndexedTester struct {
+	Query struct {
+		Users  []*queryUser `binder:"users"`
+		Admins []queryUser  `binder:"admins"`
+	}
+}
+
+func TestQueryIndexedStructSlice(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?users[0].name=a&users[1].name=b&users[1].age=3&admins[1].name=c", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(queryIndexedTester)
+	err := c.Bind(u)
+	if assert.NoError(err) && assert.Len(u.Query.Users, 2) {
+		if assert.NotNil(u.Query.Users[0]) && assert.NotNil(u.Query.Users[1]) {
+			assert.Equal(queryUser{Name: "a"}, *u.Query.Users[0])
+			assert.Equal(queryUser{Name: "b", Age: 3}, *u.Query.Users[1])
+		}
+
+		assert.Equal([]queryUser{{}, {Name: "c"}}, u.Query.Admins)
+	}
+
+	// Unparsable element values and huge indexes should fail
+	for _, query := range []string{"users[0].age=abc", "users[100000].name=a"} {
+		req = httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+
+		err = c.Bind(new(queryIndexedTester))
+		assert.Error(err, query)
+	}
+}
+
+type queryMapTester struct {
+	Query struct {
+		Filters *map[string]string   `binder:"filter"`
+		Tags    map[string][]string  `binder:"tag"`
+		Unsent  *map[string]string   `binder:"unsent"`
+		Invalid map[string]int       `binder:"invalid"`
+		Other   map[string]time.Time `binder:"-"`
+	}
+}
+
+func TestQueryMapBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?filter[status]=open&filter[type]=bug&tag[a]=1&tag[a]=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(queryMapTester)
+	err := c.Bind(u)
+	if assert.NoError(err) && assert.NotNil(u.Query.Filters) {
+		assert.Equal(map[string]string{"status": "open", "type": "bug"}, *u.Query.Filters)
+		assert.Equal(map[string][]string{"a": {"1", "2"}}, u.Query.Tags)
+		assert.Nil(u.Query.Unsent)
+	}
+
+	// Maps of values that aren't strings can't be bound
+	req = httptest.NewRequest(http.MethodGet, "/users?invalid[a]=1", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err = c.Bind(new(queryMapTester))
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "binding element at `Query.Invalid` must be a `map[string]string`")
+	}
+
+	// Maps are only bound from bracketed keys, so a plain key is an unknown param
+	req = httptest.NewRequest(http.MethodGet, "/users?filter=open&tag[a]=1", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	u = new(queryMapTester)
+	if assert.NoError(c.Bind(u)) {
+		assert.Nil(u.Query.Filters)
+		assert.Equal(map[string][]string{"a": {"1"}}, u.Query.Tags)
+	}
+
+	binder.StrictQuery(true)
+	err = c.Bind(new(queryMapTester))
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "unknown param `filter` at `Query`")
+	}
+}
+
+type formMapTester struct {
+	Form struct {
+		Filters map[string]string `binder:"filter"`
+	}
+}
+
+func TestFormMapBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("filter[status]=open&filter[type]=bug"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(formMapTester)
+	if assert.NoError(c.Bind(u)) {
+		assert.Equal(map[string]string{"status": "open", "type": "bug"}, u.Form.Filters)
+	}
+}
+
+type queryDuplicateTester struct {
+	Query struct {
+		UserId int `binder:"id"`
+		PostId int `binder:"id"`
+	}
+}
+
+type queryEmbeddedDuplicateTester struct {
+	Query struct {
+		validEmbedded
+		Name string `binder:"key1"`
+	}
+}
+
+func TestQueryDuplicateIdentifiers(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	for _, target := range []interface{}{new(queryDuplicateTester), new(queryEmbeddedDuplicateTester)} {
+		req := httptest.NewRequest(http.MethodGet, "/users?id=1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := c.Bind(target)
+		if assert.Error(err) {
+			assert.Contains(err.Error(), "duplicate binder identifier")
+			assert.Contains(err.Error(), "in Query")
+		}
+	}
+}
+
+// A body that calls done after its first chunk was read, like a client that goes away mid request
+type interruptedReader struct {
+	chunks [][]byte
+	done   func()
+}
+
+func (r *interruptedReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	r.done()
+	return n, nil
+}
+
+func TestBodyReadContextBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	bind := func(ctx context.Context, done func()) error {
+		body := &interruptedReader{chunks: [][]byte{[]byte(`{"name":`), []byte(`"binder"}`)}, done: done}
+		req := httptest.NewRequest(http.MethodPost, "/", body).WithContext(ctx)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		return c.Bind(&bodyNormalTester{})
+	}
+
+	// The client cancels the request in the middle of the body
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bind(ctx, cancel)
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.ErrorIs(err.(*echo.HTTPError).Internal, context.Canceled)
+	}
+
+	// The client is too slow to send the rest of the body before the deadline
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = bind(ctx, func() { <-ctx.Done() })
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusRequestTimeout, err.(*echo.HTTPError).Code)
+		assert.ErrorIs(err.(*echo.HTTPError).Internal, context.DeadlineExceeded)
+	}
+}
+
+func TestBodyStreamBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	newContext := func(body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	// Without the sent fields the body is decoded while it's read, so it isn't buffered for later reads
+	c := newContext(`{"name":"binder"} `)
+	data := bodyNormalTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("binder", data.Body.Name)
+
+		rest, err := io.ReadAll(c.Request().Body)
+		assert.NoError(err)
+		assert.Empty(rest)
+	}
+
+	// Just like json.Unmarshal the body must hold a single value
+	for _, body := range []string{`{"name":"binder"}{}`, `{"name":`, `{"name":1}`} {
+		assert.Error(newContext(body).Bind(&bodyNormalTester{}), body)
+	}
+
+	// With the sent fields the body is buffered, so it's tracked and can still be read
+	c = newContext(`{"name":"Omri"}`)
+	tracked := bodySentFieldsTester{}
+	if assert.NoError(c.Bind(&tracked)) {
+		assert.Equal("Omri", tracked.Body.Name)
+		assert.True(tracked.BodySentFields.FieldExists("name"))
+
+		rest, err := io.ReadAll(c.Request().Body)
+		assert.NoError(err)
+		assert.Equal(`{"name":"Omri"}`, string(rest))
+	}
+}
+
+type namedTags []string
+
+type commaTags []string
+
+func (tags *commaTags) UnmarshalText(text []byte) error {
+	*tags = strings.Split(string(text), ",")
+	return nil
+}
+
+type namedSliceTester struct {
+	Query struct {
+		Tags   namedTags  `binder:"tags"`
+		Labels commaTags  `binder:"labels"`
+		Shared *commaTags `binder:"shared"`
+	}
+
+	Form struct {
+		Tags namedTags `binder:"topics"`
+	}
+}
+
+func TestNamedSliceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	form := url.Values{"topics": {"x", "y"}}
+	req := httptest.NewRequest(http.MethodPost, "/users?tags=a&tags=b&labels=c,d&shared=e,f", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Named slices get the values as elements, unless they unmarshal the whole value themselves
+	data := namedSliceTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(namedTags{"a", "b"}, data.Query.Tags)
+		assert.Equal(commaTags{"c", "d"}, data.Query.Labels)
+		assert.Equal(&commaTags{"e", "f"}, data.Query.Shared)
+		assert.Equal(namedTags{"x", "y"}, data.Form.Tags)
+	}
+}
+
+type queryPresenceTester struct {
+	Query struct {
+		Active   bool  `binder:"active,presence"`
+		Archived *bool `binder:"archived,presence"`
+	}
+}
+
+func TestQueryPresenceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	tests := []struct {
+		query    string
+		active   bool
+		archived *bool
+	}{
+		{query: "active&archived", active: true, archived: getReference(true)},
+		{query: "active=false&archived=false", active: false, archived: getReference(false)},
+		{query: "active=true&archived=true", active: true, archived: getReference(true)},
+		{query: "", active: false, archived: nil},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users?"+test.query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		data := queryPresenceTester{}
+		if assert.NoError(c.Bind(&data), test.query) {
+			assert.Equal(test.active, data.Query.Active, test.query)
+			assert.Equal(test.archived, data.Query.Archived, test.query)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users?active=maybe", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryPresenceTester{}))
+}
+
+type strictPath struct {
+	Id int `binder:"id"`
+}
+
+type strictQueryTester struct {
+	Path  strictPath
+	Query struct {
+		Page    int               `binder:"page"`
+		Filters map[string]string `binder:"filter"`
+	}
+}
+
+type strictBodyTester struct {
+	Path strictPath
+	Body struct {
+		Name string `json:"name"`
+	}
+}
+
+type strictFormTester struct {
+	Path strictPath
+	Form struct {
+		Title string `binder:"title"`
+	}
+}
+
+func TestStrictAllBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictAll(true)
+	e.Binder = binder
+
+	newContext := func(method, target, contentType, body string) echo.Context {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set(echo.HeaderContentType, contentType)
+		}
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return c
+	}
+
+	// Only declared params are fine
+	assert.NoError(newContext(http.MethodGet, "/users/1?page=2&filter[status]=open", "", "").Bind(&strictQueryTester{}))
+	assert.NoError(newContext(http.MethodPost, "/users/1", echo.MIMEApplicationJSON, `{"name":"binder"}`).Bind(&strictBodyTester{}))
+	assert.NoError(newContext(http.MethodPost, "/users/1", echo.MIMEApplicationForm, "title=binder").Bind(&strictFormTester{}))
+
+	pathContext := newContext(http.MethodGet, "/users/1", "", "")
+	pathContext.SetParamNames("id", "extra")
+	pathContext.SetParamValues("1", "2")
+
+	tests := map[string]struct {
+		c    echo.Context
+		data interface{}
+	}{
+		"Path":  {c: pathContext, data: &strictQueryTester{}},
+		"Query": {c: newContext(http.MethodGet, "/users/1?page=2&extra=1", "", ""), data: &strictQueryTester{}},
+		"Body":  {c: newContext(http.MethodPost, "/users/1", echo.MIMEApplicationJSON, `{"name":"binder","extra":1}`), data: &strictBodyTester{}},
+		"Form":  {c: newContext(http.MethodPost, "/users/1", echo.MIMEApplicationForm, "title=binder&extra=1"), data: &strictFormTester{}},
+	}
+
+	for section, test := range tests {
+		err := test.c.Bind(test.data)
+		if assert.Error(err, section) {
+			assert.Contains(err.Error(), "extra", section)
+		}
+	}
+
+	// Without the strict mode the unknown params are ignored
+	binder.StrictAll(false)
+	assert.NoError(newContext(http.MethodGet, "/users/1?extra=1", "", "").Bind(&strictQueryTester{}))
+	assert.NoError(newContext(http.MethodPost, "/users/1", echo.MIMEApplicationJSON, `{"extra":1}`).Bind(&strictBodyTester{}))
+	assert.NoError(newContext(http.MethodPost, "/users/1", echo.MIMEApplicationForm, "extra=1").Bind(&strictFormTester{}))
+}
+
+type formTimeTester struct {
+	Form struct {
+		StartsAt time.Time  `binder:"startsAt"`
+		Birthday *time.Time `binder:"birthday"`
+		Created  time.Time  `binder:"created"`
+	}
+}
+
+func TestFormHTMLTimeBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	form := url.Values{}
+	form.Set("startsAt", "2023-01-02T15:04")
+	form.Set("birthday", "2023-01-02")
+	form.Set("created", "2023-01-02T15:04:05Z")
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := formTimeTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(time.Date(2023, 1, 2, 15, 4, 0, 0, time.UTC), data.Form.StartsAt)
+		if assert.NotNil(data.Form.Birthday) {
+			assert.Equal(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), *data.Form.Birthday)
+		}
+		assert.Equal(time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC), data.Form.Created)
+	}
+
+	form.Set("startsAt", "02/01/2023")
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&formTimeTester{}))
+
+	// The HTML input formats are only accepted from forms
+	req = httptest.NewRequest(http.MethodGet, "/?since=2023-01-02", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryTimeTester{}))
+}
+
+type bodyMergePatchTester struct {
+	Body struct {
+		Name     string  `json:"name"`
+		Age      int     `json:"age"`
+		Nickname *string `json:"nickname"`
+		Address  struct {
+			City string `json:"city"`
+			Zip  string `json:"zip"`
+		} `json:"address"`
+		Labels map[string]int `json:"labels"`
+		Tags   []string       `json:"tags"`
+		Owner  *struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"owner"`
+	}
+
+	BodySentFields RecursiveLookupTable
+}
+
+func TestBodyMergePatchBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	data := bodyMergePatchTester{}
+	data.Body.Name = "binder"
+	data.Body.Age = 3
+	data.Body.Nickname = getReference("bind")
+	data.Body.Address.City = "Tel Aviv"
+	data.Body.Address.Zip = "61000"
+
+	patch := `{"name":"echo-binder","nickname":null,"address":{"city":"Haifa"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(patch))
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json; charset=utf-8")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("echo-binder", data.Body.Name)
+		assert.Equal(3, data.Body.Age)
+		assert.Nil(data.Body.Nickname)
+		assert.Equal("Haifa", data.Body.Address.City)
+		assert.Equal("61000", data.Body.Address.Zip)
+
+		assert.True(data.BodySentFields.FieldExists("name"))
+		assert.True(data.BodySentFields.FieldExists("address.city"))
+		assert.False(data.BodySentFields.FieldExists("age"))
+		assert.False(data.BodySentFields.FieldExists("address.zip"))
+	}
+
+	// Null members delete the keys of maps and reset fields, objects are merged into maps and pointers while
+	// arrays replace the current slice
+	data = bodyMergePatchTester{}
+	data.Body.Name = "x"
+	data.Body.Age = 3
+	data.Body.Labels = map[string]int{"a": 1, "b": 2}
+	data.Body.Tags = []string{"a", "b"}
+
+	patch = `{"labels":{"a":null,"c":3},"name":null,"age":null,"tags":["c"],"owner":{"name":"omri","email":null}}`
+	req = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(patch))
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(map[string]int{"b": 2, "c": 3}, data.Body.Labels)
+		assert.Equal("", data.Body.Name)
+		assert.Equal(0, data.Body.Age)
+		assert.Equal([]string{"c"}, data.Body.Tags)
+		if assert.NotNil(data.Body.Owner) {
+			assert.Equal("omri", data.Body.Owner.Name)
+			assert.Equal("", data.Body.Owner.Email)
+		}
+	}
+
+	data.Body.Owner.Email = "omri@example.com"
+	req = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"owner":null,"labels":null}`))
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	if assert.NoError(c.Bind(&data)) {
+		assert.Nil(data.Body.Owner)
+		assert.Nil(data.Body.Labels)
+		assert.Equal([]string{"c"}, data.Body.Tags)
+	}
+
+	// The patch is still rejected when its members don't match the types of the fields
+	req = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"labels":{"a":"one"}}`))
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	assert.Error(c.Bind(&bodyMergePatchTester{}))
+}
+
+type clientCertTester struct {
+	ClientCert struct {
+		CommonName   string   `validate:"required"`
+		DNSNames     []string `binder:"DNSNames"`
+		SerialNumber string
+		Emails       []string `binder:"EmailAddresses"`
+	}
+}
+
+type optionalClientCertTester struct {
+	ClientCert struct {
+		CommonName string
+	}
+}
+
+func TestClientCertBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	cert := &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "client.example.com"},
+		SerialNumber: big.NewInt(1337),
+		DNSNames:     []string{"client.example.com", "api.example.com"},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := clientCertTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("client.example.com", data.ClientCert.CommonName)
+		assert.Equal([]string{"client.example.com", "api.example.com"}, data.ClientCert.DNSNames)
+		assert.Equal("1337", data.ClientCert.SerialNumber)
+		assert.Nil(data.ClientCert.Emails)
+	}
+
+	// Without TLS the fields are left untouched, unless they are required
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	optional := optionalClientCertTester{}
+	if assert.NoError(c.Bind(&optional)) {
+		assert.Empty(optional.ClientCert.CommonName)
+	}
+
+	assert.Error(c.Bind(&clientCertTester{}))
+}
+
+type requestTester struct {
+	Path struct {
+		Id int `binder:"id"`
+	}
+
+	Request struct {
+		RoutePattern string
+		Method       string
+		Path         string `binder:"URLPath"`
+	}
+}
+
+func TestRequestBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	data := requestTester{}
+	e.GET("/users/:id", func(c echo.Context) error {
+		data = requestTester{}
+		return c.Bind(&data)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42?page=1", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if assert.Equal(http.StatusOK, rec.Code) {
+		assert.Equal(42, data.Path.Id)
+		assert.Equal("/users/:id", data.Request.RoutePattern)
+		assert.Equal(http.MethodGet, data.Request.Method)
+		assert.Equal("/users/42", data.Request.Path)
+	}
+
+	// A context that wasn't routed has no pattern
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/users/42", nil), httptest.NewRecorder())
+	data = requestTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Empty(data.Request.RoutePattern)
+		assert.Equal(http.MethodPost, data.Request.Method)
+	}
+}
+
+type pagingMixin struct {
+	Query struct {
+		Page  int `binder:"page"`
+		Limit int `binder:"limit" default:"20"`
+	}
+}
+
+type AuthMixin struct {
+	Header struct {
+		Token string `binder:"Authorization"`
+	}
+}
+
+type embeddedSectionsTester struct {
+	pagingMixin
+	*AuthMixin
+
+	Path struct {
+		Id int `binder:"id"`
+	}
+}
+
+type shadowedSectionTester struct {
+	pagingMixin
+
+	Query struct {
+		Search string `binder:"q"`
+	}
+}
+
+type invalidSectionMixin struct {
+	Query string
+}
+
+func TestEmbeddedSectionsBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1?page=3&q=koren", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// The sections of the embedded structs are bound, and embedded pointers are allocated
+	data := embeddedSectionsTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(3, data.Query.Page)
+		assert.Equal(20, data.Query.Limit)
+		if assert.NotNil(data.AuthMixin) {
+			assert.Equal("Bearer token", data.Header.Token)
+		}
+		assert.Equal(1, data.Path.Id)
+	}
+	assert.NoError(binder.ValidateSchema(&data))
+
+	// A section of the request struct itself hides the one of the embedded struct
+	shadowed := shadowedSectionTester{}
+	if assert.NoError(c.Bind(&shadowed)) {
+		assert.Equal("koren", shadowed.Query.Search)
+		assert.Zero(shadowed.pagingMixin.Query.Page)
+	}
+
+	// The sections of the embedded structs are checked just like the other ones
+	invalid := struct{ invalidSectionMixin }{}
+	assert.Error(c.Bind(&invalid))
+	assert.Error(binder.ValidateSchema(&invalid))
+}
+
+type reportTester struct {
+	Path struct {
+		Id int `binder:"id"`
+	}
+
+	Query struct {
+		Page  int `binder:"page"`
+		Limit int `binder:"limit" default:"10"`
+	}
+
+	Header struct {
+		UserAgent string `binder:"User-Agent"`
+	}
+}
+
+func TestBindReport(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.RecordReport(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1?page=2&extra=1", nil)
+	req.Header.Set("User-Agent", "binder")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	assert.NoError(c.Bind(&reportTester{}))
+
+	report := ReportFromContext(c)
+	if assert.NotNil(report) {
+		assert.ElementsMatch([]ReportField{
+			{Section: "Path", Param: "id", FieldName: "Id"},
+			{Section: "Query", Param: "page", FieldName: "Page"},
+			{Section: "Query", Param: "limit", FieldName: "Limit", Default: true},
+			{Section: "Header", Param: "User-Agent", FieldName: "UserAgent"},
+		}, report.Fields)
+
+		assert.Equal(map[string][]string{"Query": {"extra"}}, report.SkippedParams)
+		assert.Contains(report.SectionDurations, "Path")
+		assert.Contains(report.SectionDurations, "Query")
+		assert.Contains(report.SectionDurations, "Header")
+		assert.GreaterOrEqual(report.Duration, report.SectionDurations["Query"])
+	}
+
+	// Reports are only recorded when enabled
+	binder.RecordReport(false)
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.NoError(c.Bind(&reportTester{}))
+	assert.Nil(ReportFromContext(c))
+}
+
+type queryInterfaceTester struct {
+	Query struct {
+		Count  interface{}   `binder:"count"`
+		Ratio  any           `binder:"ratio"`
+		Active interface{}   `binder:"active"`
+		Name   interface{}   `binder:"name"`
+		Values []interface{} `binder:"values"`
+	}
+}
+
+func TestQueryInterfaceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	target := "/users?count=3&ratio=0.5&active=true&name=binder&values=1&values=a"
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryInterfaceTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("3", data.Query.Count)
+		assert.Equal("0.5", data.Query.Ratio)
+		assert.Equal("true", data.Query.Active)
+		assert.Equal("binder", data.Query.Name)
+		assert.Equal([]interface{}{"1", "a"}, data.Query.Values)
+	}
+
+	binder.InferScalarTypes(true)
+
+	req = httptest.NewRequest(http.MethodGet, target, nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	data = queryInterfaceTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(int64(3), data.Query.Count)
+		assert.Equal(0.5, data.Query.Ratio)
+		assert.Equal(true, data.Query.Active)
+		assert.Equal("binder", data.Query.Name)
+		assert.Equal([]interface{}{int64(1), "a"}, data.Query.Values)
+	}
+}
+
+type queryFilter interface {
+	Matches(value string) bool
+}
+
+type prefixFilter struct {
+	Prefix string
+}
+
+func (filter *prefixFilter) UnmarshalParam(param string) error {
+	filter.Prefix = strings.TrimSuffix(param, "*")
+	return nil
+}
+
+func (filter *prefixFilter) Matches(value string) bool {
+	return strings.HasPrefix(value, filter.Prefix)
+}
+
+type lengthFilter int
+
+func (filter *lengthFilter) UnmarshalText(text []byte) error {
+	length, err := strconv.Atoi(string(text))
+	*filter = lengthFilter(length)
+	return err
+}
+
+func (filter lengthFilter) Matches(value string) bool {
+	return len(value) == int(filter)
+}
+
+type queryUnmarshalerInterfaceTester struct {
+	Query struct {
+		Name   queryFilter `binder:"name"`
+		Length queryFilter `binder:"length"`
+		Nil    queryFilter `binder:"nil"`
+		Any    interface{} `binder:"any"`
+	}
+}
+
+func TestQueryUnmarshalerInterfaceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.InferScalarTypes(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?name=avi*&length=4&nil=a*&any=5", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	// The concrete types are picked by setting the interfaces before the binding, nil pointers are allocated
+	data := queryUnmarshalerInterfaceTester{}
+	data.Query.Name = &prefixFilter{}
+	data.Query.Length = lengthFilter(0)
+	data.Query.Nil = (*prefixFilter)(nil)
+	data.Query.Any = &prefixFilter{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(&prefixFilter{Prefix: "avi"}, data.Query.Name)
+		assert.Equal(lengthFilter(4), data.Query.Length)
+		assert.Equal(&prefixFilter{Prefix: "a"}, data.Query.Nil)
+		assert.Equal(&prefixFilter{Prefix: "5"}, data.Query.Any)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?length=four", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data = queryUnmarshalerInterfaceTester{}
+	data.Query.Length = lengthFilter(0)
+	assert.Error(c.Bind(&data))
+
+	// Without a value to unmarshal into, a converter of the interface type can create the concrete value
+	binder.RegisterConverter(reflect.TypeOf((*queryFilter)(nil)).Elem(), func(value string) (interface{}, error) {
+		if length, err := strconv.Atoi(value); err == nil {
+			return lengthFilter(length), nil
+		}
+
+		return &prefixFilter{Prefix: strings.TrimSuffix(value, "*")}, nil
+	})
+
+	req = httptest.NewRequest(http.MethodGet, "/users?name=avi*&length=4", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data = queryUnmarshalerInterfaceTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(&prefixFilter{Prefix: "avi"}, data.Query.Name)
+		assert.Equal(lengthFilter(4), data.Query.Length)
+		assert.Nil(data.Query.Nil)
+	}
+}
+
+func TestMaxQueryParams(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.SetMaxQueryParams(2)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?Name=a&Data=1&Data=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	assert.NoError(c.Bind(&queryTester{}))
+
+	req = httptest.NewRequest(http.MethodGet, "/users?Name=a&Data=1&a=1&b=2", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&queryTester{})
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(err.Error(), "too many params at `Query`")
+	}
+}
+
+type queryDurationTester struct {
+	Query struct {
+		Timeout  time.Duration   `binder:"timeout"`
+		Retry    *time.Duration  `binder:"retry"`
+		Empty    time.Duration   `binder:"empty"`
+		Backoffs []time.Duration `binder:"backoffs"`
+		Missing  *time.Duration  `binder:"missing"`
+	}
+}
+
+func TestQueryDurationBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?timeout=30s&retry=1500ms&empty=&backoffs=1s&backoffs=1m", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryDurationTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(30*time.Second, data.Query.Timeout)
+		assert.Equal(getReference(1500*time.Millisecond), data.Query.Retry)
+		assert.Equal(time.Duration(0), data.Query.Empty)
+		assert.Equal([]time.Duration{time.Second, time.Minute}, data.Query.Backoffs)
+		assert.Nil(data.Query.Missing)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?timeout=30", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryDurationTester{}))
+}
+
+type queryRegexpTester struct {
+	Query struct {
+		Pattern  *regexp.Regexp   `binder:"pattern"`
+		Value    regexp.Regexp    `binder:"value"`
+		Patterns []*regexp.Regexp `binder:"patterns"`
+		Missing  *regexp.Regexp   `binder:"missing"`
+	}
+}
+
+func TestQueryRegexpBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	query := url.Values{"pattern": {"^foo.*"}, "value": {"[0-9]+"}, "patterns": {"a", "b$"}}
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryRegexpTester{}
+	if assert.NoError(c.Bind(&data)) && assert.NotNil(data.Query.Pattern) {
+		assert.Equal("^foo.*", data.Query.Pattern.String())
+		assert.True(data.Query.Pattern.MatchString("foobar"))
+		assert.True(data.Query.Value.MatchString("a1"))
+		if assert.Len(data.Query.Patterns, 2) {
+			assert.Equal("b$", data.Query.Patterns[1].String())
+		}
+		assert.Nil(data.Query.Missing)
+	}
+
+	// Invalid and too long patterns should fail the binding
+	for _, pattern := range []string{"(foo", strings.Repeat("a", 1001)} {
+		req = httptest.NewRequest(http.MethodGet, "/users?pattern="+url.QueryEscape(pattern), nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		assert.Error(c.Bind(&queryRegexpTester{}))
+	}
+}
+
+type querySliceElementsTester struct {
+	Query struct {
+		Ids   []uint   `binder:"ids"`
+		Small []uint16 `binder:"small"`
+	}
+}
+
+type cents int64
+
+func (amount *cents) Scan(src interface{}) error {
+	value, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported type %T", src)
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	parsed, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", 2-len(fraction)), 10, 64)
+	*amount = cents(parsed)
+	return err
+}
+
+type queryScannerTester struct {
+	Query struct {
+		Price   cents          `binder:"price"`
+		Prices  []cents        `binder:"prices"`
+		Name    sql.NullString `binder:"name"`
+		Count   *sql.NullInt64 `binder:"count"`
+		Missing sql.NullString `binder:"missing"`
+	}
+}
+
+func TestQueryScannerBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	req := httptest.NewRequest(http.MethodGet, "/users?price=12.5&prices=1&prices=0.99&name=binder&count=3", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	data := queryScannerTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(cents(1250), data.Query.Price)
+		assert.Equal([]cents{100, 99}, data.Query.Prices)
+		assert.Equal(sql.NullString{String: "binder", Valid: true}, data.Query.Name)
+		assert.Equal(&sql.NullInt64{Int64: 3, Valid: true}, data.Query.Count)
+		assert.Equal(sql.NullString{}, data.Query.Missing)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?count=three", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.Error(c.Bind(&queryScannerTester{}))
+}
+
+type queryBytesTester struct {
+	Query struct {
+		Signature []byte  `binder:"sig"`
+		Nonce     []byte  `binder:"nonce" encoding:"hex"`
+		Token     *[]byte `binder:"token" encoding:"base64"`
+		Missing   *[]byte `binder:"missing"`
+	}
+
+	Header struct {
+		Signature []byte `binder:"X-Signature" encoding:"hex"`
+	}
+}
+
+type queryInvalidEncodingTester struct {
+	Query struct {
+		Signature []byte `binder:"sig" encoding:"base32"`
+	}
+}
+
+type queryUnexplodedBytesTester struct {
+	Query struct {
+		Signature []byte `binder:"sig" explode:"false"`
+	}
+}
+
+func TestQueryBytesBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	signature := []byte{0xfb, 0xff, 0x01}
+	query := url.Values{
+		"sig":   {base64.RawURLEncoding.EncodeToString(signature)},
+		"nonce": {"deadbeef"},
+		"token": {base64.URLEncoding.EncodeToString([]byte("token"))},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	req.Header.Set("X-Signature", "fbff01")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	// The bytes are decoded from a single value, URL-safe base64 (with or without padding) by default
+	data := queryBytesTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(signature, data.Query.Signature)
+		assert.Equal([]byte{0xde, 0xad, 0xbe, 0xef}, data.Query.Nonce)
+		assert.Equal(getReference([]byte("token")), data.Query.Token)
+		assert.Nil(data.Query.Missing)
+		assert.Equal(signature, data.Header.Signature)
+	}
+
+	tests := map[string]string{
+		"sig=a*b":    "malformed param `sig` at `Query`",
+		"nonce=xyz1": "malformed param `nonce` at `Query`",
+	}
+
+	for query, message := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		err := c.Bind(&queryBytesTester{})
+		if assert.Error(err, query) {
+			assert.Contains(err.Error(), message, query)
+		}
+	}
+
+	for _, query := range []string{"sig=", "sig=,"} {
+		req := httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		// An unexploded value without any element is treated as a param that wasn't sent
+		data := queryUnexplodedBytesTester{}
+		if assert.NoError(c.Bind(&data), query) {
+			assert.Nil(data.Query.Signature, query)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?sig=abc", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(c.Bind(&queryInvalidEncodingTester{}), &httpError) {
+		assert.Equal(http.StatusInternalServerError, httpError.Code)
+	}
+}
+
+type formSliceElementsTester struct {
+	Form struct {
+		Scores []float64 `binder:"scores"`
+	}
+}
+
+func TestSliceElementErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	tests := map[string]string{
+		"ids=1&ids=-5&ids=abc": "query `ids[1]` must be a non-negative integer, got `-5`",
+		"ids=1&ids=2&ids=abc":  "query `ids[2]` must be a non-negative integer, got `abc`",
+		"small=70000":          "query `small[0]` is out of range for uint16, got `70000`",
+	}
+
+	for query, message := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := c.Bind(&querySliceElementsTester{})
+		if assert.Error(err, query) && assert.IsType(&echo.HTTPError{}, err) {
+			assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+			assert.Equal(message, err.(*echo.HTTPError).Message, query)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("scores=1.5&scores=high"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := c.Bind(&formSliceElementsTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "form `scores[1]` must be a number, got `high`")
+	}
+}
+
+type headerAbsentFalseTester struct {
+	Header struct {
+		Debug    *bool `binder:"X-Debug,absentfalse"`
+		DryRun   bool  `binder:"X-Dry-Run,absentfalse"`
+		Optional *bool `binder:"X-Optional"`
+	}
+}
+
+func TestHeaderAbsentFalseBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	tests := []struct {
+		header   string
+		expected *bool
+	}{
+		{"true", getReference(true)},
+		{"false", getReference(false)},
+		{"", getReference(false)},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		if test.header != "" {
+			req.Header.Set("X-Debug", test.header)
+			req.Header.Set("X-Dry-Run", test.header)
+		}
+
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		// Absent headers are set to false instead of leaving the pointers nil, unless the option is missing
+		data := headerAbsentFalseTester{}
+		if assert.NoError(c.Bind(&data), test.header) {
+			assert.Equal(test.expected, data.Header.Debug, test.header)
+			assert.Equal(*test.expected, data.Header.DryRun, test.header)
+			assert.Nil(data.Header.Optional, test.header)
+		}
+	}
+
+	// The option only makes sense for booleans
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/users", nil), httptest.NewRecorder())
+
+	httpError := &echo.HTTPError{}
+	invalid := struct {
+		Header struct {
+			Name string `binder:"X-Name,absentfalse"`
+		}
+	}{}
+	if assert.ErrorAs(c.Bind(&invalid), &httpError) {
+		assert.Equal(http.StatusInternalServerError, httpError.Code)
+	}
+}
+
+type environment string
+
+const (
+	environmentProduction environment = "production"
+	environmentStaging    environment = "staging"
+)
+
+type headerCaseTester struct {
+	Header struct {
+		Env    environment `binder:"X-Env,lower" validate:"oneof=production staging"`
+		Region string      `binder:"X-Region,upper"`
+		Raw    string      `binder:"X-Raw"`
+	}
+}
+
+func TestHeaderCaseTransformBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	for _, value := range []string{"PRODUCTION", "Production", "production"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Env", value)
+		req.Header.Set("X-Region", "eu-west-1")
+		req.Header.Set("X-Raw", "MiXeD")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		data := headerCaseTester{}
+		if assert.NoError(c.Bind(&data), value) {
+			assert.Equal(environmentProduction, data.Header.Env)
+			assert.Equal("EU-WEST-1", data.Header.Region)
+			assert.Equal("MiXeD", data.Header.Raw)
+		}
+	}
+}
+
+// A stand in for uuid.UUID, so the tests don't depend on a uuid package
+type testUUID [16]byte
+
+func parseTestUUID(value string) (interface{}, error) {
+	raw, err := hex.DecodeString(strings.ReplaceAll(value, "-", ""))
+	if err != nil || len(raw) != 16 {
+		return nil, errors.New("invalid uuid")
+	}
+
+	var id testUUID
+	copy(id[:], raw)
+	return id, nil
+}
+
+type converterTester struct {
+	Path struct {
+		Id testUUID `binder:"id"`
+	}
+
+	Query struct {
+		Self   testUUID    `binder:"self"`
+		Parent *testUUID   `binder:"parent"`
+		Ids    []testUUID  `binder:"ids"`
+		Others []*testUUID `binder:"others"`
+	}
+}
+
+func TestRegisterConverter(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.RegisterConverter(reflect.TypeOf(testUUID{}), parseTestUUID)
+	e.Binder = binder
+
+	first, _ := parseTestUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	second, _ := parseTestUUID("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+
+	newContext := func(id, query string) echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+id+"?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c
+	}
+
+	c := newContext("6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"self=6ba7b811-9dad-11d1-80b4-00c04fd430c8&parent=6ba7b811-9dad-11d1-80b4-00c04fd430c8&ids=6ba7b810-9dad-11d1-80b4-00c04fd430c8&ids=6ba7b811-9dad-11d1-80b4-00c04fd430c8&others=6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+
+	data := converterTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(first, data.Path.Id)
+		assert.Equal(second, data.Query.Self)
+		assert.Equal(second, *data.Query.Parent)
+		assert.Equal([]testUUID{first.(testUUID), second.(testUUID)}, data.Query.Ids)
+		if assert.Len(data.Query.Others, 1) {
+			assert.Equal(second, *data.Query.Others[0])
+		}
+	}
+
+	err := newContext("not-a-uuid", "").Bind(&converterTester{})
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(err.Error(), "field `Id` at `Path`")
+		assert.Contains(err.Error(), "invalid uuid")
+	}
+
+	err = newContext("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "ids=bad").Bind(&converterTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "field `Ids` at `Query`")
+	}
+
+	// Converters that return a value of another type are a server error
+	binder.RegisterConverter(reflect.TypeOf(testUUID{}), func(string) (interface{}, error) { return "id", nil })
+	err = newContext("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "").Bind(&converterTester{})
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+	}
+}
+
+type cookieTester struct {
+	Cookie struct {
+		Session string  `binder:"session_id"`
+		Theme   string  `binder:"theme" default:"light"`
+		Visits  int     `binder:"visits"`
+		Missing *string `binder:"missing"`
+	}
+}
+
+type invalidCookieTester struct {
+	Cookie string
+}
+
+func TestCookieBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "abc123"})
+	req.AddCookie(&http.Cookie{Name: "visits", Value: "3"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := cookieTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("abc123", data.Cookie.Session)
+		assert.Equal("light", data.Cookie.Theme)
+		assert.Equal(3, data.Cookie.Visits)
+		assert.Nil(data.Cookie.Missing)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "visits", Value: "many"})
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&cookieTester{}))
+
+	err := c.Bind(&invalidCookieTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "binding element at `Cookie` must be a `struct`")
+	}
+}
+
+type queryIntBoolTester struct {
+	Query struct {
+		Flag    bool   `binder:"flag,intbool"`
+		Pointer *bool  `binder:"pointer,intbool"`
+		Flags   []bool `binder:"flags,intbool"`
+		Strict  bool   `binder:"strict"`
+	}
+}
+
+func TestQueryIntBoolBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	tests := map[string]bool{"2": true, "0": false, "-1": true, "1": true, "true": true, "false": false}
+
+	for value, expected := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?flag="+value+"&pointer="+value+"&flags=0&flags="+value, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		data := queryIntBoolTester{}
+		if assert.NoError(c.Bind(&data), value) {
+			assert.Equal(expected, data.Query.Flag, value)
+			assert.Equal(getReference(expected), data.Query.Pointer, value)
+			assert.Equal([]bool{false, expected}, data.Query.Flags, value)
+		}
+	}
+
+	// Without the option only 0 and 1 are accepted of the integers
+	req := httptest.NewRequest(http.MethodGet, "/?strict=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryIntBoolTester{}))
+}
+
+type queryBoolValuesTester struct {
+	Query struct {
+		Flag    bool   `binder:"flag"`
+		Pointer *bool  `binder:"pointer"`
+		Flags   []bool `binder:"flags"`
+	}
+
+	Form struct {
+		Remember bool `binder:"remember"`
+	}
+}
+
+func TestBoolValuesBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	tests := map[string]bool{
+		"on": true, "off": false, "yes": true, "no": false, "y": true, "n": false, "enabled": true, "disabled": false,
+		"ON": true, "Yes": true, "True": true, "F": false, "1": true, "0": false, "": false,
+	}
+
+	for value, expected := range tests {
+		query := url.Values{"flag": {value}, "pointer": {value}, "flags": {"on", value}}
+		req := httptest.NewRequest(http.MethodPost, "/?"+query.Encode(), nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		data := queryBoolValuesTester{}
+		if assert.NoError(c.Bind(&data), value) {
+			assert.Equal(expected, data.Query.Flag, value)
+			assert.Equal(getReference(expected), data.Query.Pointer, value)
+			assert.Equal([]bool{true, expected}, data.Query.Flags, value)
+		}
+	}
+
+	// The whitespace around the values is trimmed, and checkboxes of forms are sent as `on`
+	req := httptest.NewRequest(http.MethodPost, "/?flag=+1+", strings.NewReader("remember=on"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	data := queryBoolValuesTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.True(data.Query.Flag)
+		assert.True(data.Form.Remember)
+	}
+
+	invalid := map[string]string{
+		"flag=maybe":           "query `flag` must be a boolean, got `maybe`",
+		"flags=on&flags=maybe": "query `flags[1]` must be a boolean, got `maybe`",
+	}
+
+	for query, message := range invalid {
+		req := httptest.NewRequest(http.MethodPost, "/?"+query, nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		err := c.Bind(&queryBoolValuesTester{})
+		if assert.Error(err, query) {
+			assert.Contains(err.Error(), message, query)
+		}
+	}
+
+	// The accepted values can be replaced
+	binder.SetBoolValues([]string{"Ja"}, []string{"Nein"})
+
+	req = httptest.NewRequest(http.MethodPost, "/?flag=ja&flags=nein", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data = queryBoolValuesTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.True(data.Query.Flag)
+		assert.Equal([]bool{false}, data.Query.Flags)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/?flag=true", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.Error(c.Bind(&queryBoolValuesTester{}))
+}
+
+type queryMethodsTester struct {
+	Query struct {
+		DryRun bool `binder:"dry_run"`
+	}
+}
+
+func TestQueryMethodsBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/users?dry_run=true", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		data := queryMethodsTester{}
+		if assert.NoError(c.Bind(&data), method) {
+			assert.True(data.Query.DryRun, method)
+		}
+	}
+
+	// The methods can be restricted, and the restriction can be lifted again
+	binder.SetQueryMethods(http.MethodGet, "delete")
+
+	req := httptest.NewRequest(http.MethodDelete, "/users?dry_run=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	assert.NoError(c.Bind(&queryMethodsTester{}))
+
+	req = httptest.NewRequest(http.MethodPatch, "/users?dry_run=true", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryMethodsTester{}))
+
+	binder.SetQueryMethods()
+	assert.NoError(c.Bind(&queryMethodsTester{}))
+}
+
+type queryMaxTester struct {
+	Query struct {
+		Tags  []string `binder:"tags,max=3"`
+		IDs   []int    `binder:"ids,max=2" explode:"false"`
+		Plain []string `binder:"plain"`
+	}
+}
+
+func TestQueryMaxBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?tags=a&tags=b&tags=c&tags=d&tags=e&ids=1,2,3,x&plain=a&plain=b&plain=c&plain=d", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryMaxTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([]string{"a", "b", "c"}, data.Query.Tags)
+		// The dropped values aren't parsed at all
+		assert.Equal([]int{1, 2}, data.Query.IDs)
+		assert.Equal([]string{"a", "b", "c", "d"}, data.Query.Plain)
+	}
+
+	// Up to the limit all of the values are bound
+	req = httptest.NewRequest(http.MethodGet, "/users?tags=a&tags=b", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	data = queryMaxTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([]string{"a", "b"}, data.Query.Tags)
+	}
+
+	err := c.Bind(&struct {
+		Query struct {
+			Tags []string `binder:"tags,max=many"`
+		}
+	}{})
+	if assert.Error(err) {
+		assert.Equal(http.StatusInternalServerError, err.(*echo.HTTPError).Code)
+	}
+}
+
+type queryNegativeUintTester struct {
+	Query struct {
+		Count   uint    `binder:"count"`
+		Limit   *uint16 `binder:"limit"`
+		Offsets []uint  `binder:"offsets"`
+	}
+
+	Header struct {
+		Retries uint8 `binder:"X-Retries"`
+	}
+}
+
+func TestQueryNegativeUintBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	tests := map[string]string{
+		"/users?count=-1":             "query param `count` must be a non-negative integer, got `-1`",
+		"/users?limit=-20":            "query param `limit` must be a non-negative integer, got `-20`",
+		"/users?offsets=1&offsets=-2": "query `offsets[1]` must be a non-negative integer, got `-2`",
+	}
+
+	for target, message := range tests {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := c.Bind(&queryNegativeUintTester{})
+		if assert.Error(err, target) {
+			assert.Contains(err.Error(), message, target)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Retries", "-3")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := c.Bind(&queryNegativeUintTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "header param `X-Retries` must be a non-negative integer, got `-3`")
+	}
+}
+
+type customQuerySection struct {
+	Terms []string
+}
+
+func (section *customQuerySection) BindSection(c echo.Context) error {
+	search := c.QueryParam("q")
+	if search == "" {
+		return errors.New("missing search")
+	}
+
+	section.Terms = strings.Fields(search)
+	return nil
+}
+
+type sectionBinderTester struct {
+	Query *customQuerySection
+
+	Header struct {
+		Version string `binder:"X-Version" validate:"required"`
+	}
+}
+
+func TestSectionBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictQuery(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?q=echo+binder&unknown=1", nil)
+	req.Header.Set("X-Version", "v2")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := sectionBinderTester{}
+	if assert.NoError(c.Bind(&data)) && assert.NotNil(data.Query) {
+		assert.Equal([]string{"echo", "binder"}, data.Query.Terms)
+		assert.Equal("v2", data.Header.Version)
+	}
+
+	// The errors of the section fail the binding, and the other sections are still validated
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Version", "v2")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&sectionBinderTester{})
+	if assert.Error(err) {
+		assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Contains(err.Error(), "missing search")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?q=echo", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&sectionBinderTester{}))
+
+	assert.NoError(binder.ValidateSchema(&sectionBinderTester{}))
+}
+
+type timeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+type queryRangeTester struct {
+	Query struct {
+		Range   timeRange  `binder:"range,rangesep=.." time_format:"2006-01-02"`
+		Created *timeRange `binder:"created,rangesep=~"`
+		Missing *timeRange `binder:"missing,rangesep=.."`
+		Ages    *struct {
+			Start int
+			End   int
+		} `binder:"ages,rangesep=-"`
+	}
+}
+
+func TestQueryRangeBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?range=2023-01-01..2023-02-01&created=2023-01-01T10:00:00Z~2023-01-02T10:00:00Z&ages=18-30", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryRangeTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(timeRange{
+			Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		}, data.Query.Range)
+		if assert.NotNil(data.Query.Created) {
+			assert.Equal(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC), data.Query.Created.End)
+		}
+		assert.Nil(data.Query.Missing)
+		if assert.NotNil(data.Query.Ages) {
+			assert.Equal(18, data.Query.Ages.Start)
+			assert.Equal(30, data.Query.Ages.End)
+		}
+	}
+
+	tests := map[string]string{
+		"range=2023-01-01":             "a range must have both a start and an end separated by `..`",
+		"range=..2023-02-01":           "a range must have both a start and an end separated by `..`",
+		"range=2023-01-01..2023-13-01": "invalid `End` of the range",
+		"range=yesterday..2023-02-01":  "invalid `Start` of the range",
+	}
+
+	for query, message := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := c.Bind(&queryRangeTester{})
+		if assert.Error(err, query) {
+			assert.Contains(err.Error(), "malformed param `range` at `Query`", query)
+			assert.Contains(err.Error(), message, query)
+		}
+	}
+
+	err := c.Bind(&struct {
+		Query struct {
+			Range struct{ From time.Time } `binder:"range,rangesep=.."`
+		}
+	}{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "must be a `struct { Start time.Time; End time.Time }`")
+	}
+}
+
+type queryArrayTester struct {
+	Query struct {
+		Point   [3]float64 `binder:"point"`
+		Coords  [2]int     `binder:"coords" explode:"false"`
+		Tags    [2]string  `binder:"tags,truncate"`
+		Partial [3]int     `binder:"partial"`
+	}
+}
+
+type formArrayTester struct {
+	Form struct {
+		Point [2]int `binder:"point"`
+	}
+}
+
+func TestArrayBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?point=1.5&point=2&point=3&coords=4,5&tags=a&tags=b&tags=c&partial=7", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryArrayTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([3]float64{1.5, 2, 3}, data.Query.Point)
+		assert.Equal([2]int{4, 5}, data.Query.Coords)
+		assert.Equal([2]string{"a", "b"}, data.Query.Tags)
+		assert.Equal([3]int{7, 0, 0}, data.Query.Partial)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?coords=1,2,3", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&queryArrayTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "query `coords` accepts at most 2 values, got 3")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("point=1&point=2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	form := formArrayTester{}
+	if assert.NoError(c.Bind(&form)) {
+		assert.Equal([2]int{1, 2}, form.Form.Point)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("point=1&point=x"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err = c.Bind(&formArrayTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "form `point[1]` must be an integer, got `x`")
+	}
+}
+
+type queryJSONArrayTester struct {
+	Query struct {
+		IDs   []int     `binder:"ids,jsonarray"`
+		Names []string  `binder:"names,jsonarray"`
+		Refs  []*string `binder:"refs,jsonarray"`
+	}
+}
+
+type formJSONArrayTester struct {
+	Form struct {
+		Names []string `binder:"names,jsonarray"`
+	}
+}
+
+type queryUnexplodedJSONArrayTester struct {
+	Query struct {
+		IDs   []int `binder:"ids,jsonarray" explode:"false"`
+		Pipes []int `binder:"pipes,jsonarray,style=pipeDelimited"`
+	}
+}
+
+func TestJSONArrayBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	query := url.Values{"ids": {"[1,2,3]"}, "names": {`["a","b,c"]`}, "refs": {`["x",null]`}}
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryJSONArrayTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([]int{1, 2, 3}, data.Query.IDs)
+		assert.Equal([]string{"a", "b,c"}, data.Query.Names)
+		assert.Equal([]*string{getReference("x"), nil}, data.Query.Refs)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(url.Values{"names": {`["a","b"]`}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	form := formJSONArrayTester{}
+	if assert.NoError(c.Bind(&form)) {
+		assert.Equal([]string{"a", "b"}, form.Form.Names)
+	}
+
+	for _, value := range []string{"1,2,3", `["a"]`, "[1,2"} {
+		req = httptest.NewRequest(http.MethodGet, "/users?ids="+url.QueryEscape(value), nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+
+		err := c.Bind(&queryJSONArrayTester{})
+		if assert.Error(err, value) {
+			assert.Contains(err.Error(), "malformed param `ids` at `Query`", value)
+		}
+	}
+
+	// Unexploded params without any element are treated as params that weren't sent
+	for _, query := range []string{"ids=&pipes=", "ids=,&pipes=%7C"} {
+		req = httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+
+		unexploded := queryUnexplodedJSONArrayTester{}
+		if assert.NoError(c.Bind(&unexploded), query) {
+			assert.Nil(unexploded.Query.IDs, query)
+			assert.Nil(unexploded.Query.Pipes, query)
+		}
+	}
+}
+
+type unquoteTester struct {
+	Query struct {
+		Name    string   `binder:"name,unquote"`
+		Raw     string   `binder:"raw"`
+		Count   int      `binder:"count,unquote"`
+		Tags    []string `binder:"tags,unquote"`
+		Invalid string   `binder:"invalid,unquote"`
+	}
+
+	Form struct {
+		Title string `binder:"title,unquote"`
+	}
+}
+
+func TestUnquoteBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	query := url.Values{
+		"name":    {`"Omri"`},
+		"raw":     {`"Omri"`},
+		"count":   {`"3"`},
+		"tags":    {`"a"`, `b`, `"c\"d"`, `"`},
+		"invalid": {`"a\qb"`},
+	}
+	form := url.Values{"title": {`"Hello, \"world\""`}}
+	req := httptest.NewRequest(http.MethodPost, "/users?"+query.Encode(), strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := unquoteTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Omri", data.Query.Name)
+		assert.Equal(`"Omri"`, data.Query.Raw)
+		assert.Equal(3, data.Query.Count)
+		assert.Equal([]string{"a", "b", `c"d`, `"`}, data.Query.Tags)
+		// Invalid escapes only lose their quotes
+		assert.Equal(`a\qb`, data.Query.Invalid)
+		assert.Equal(`Hello, "world"`, data.Form.Title)
+	}
+}
+
+type queryUnderscoresTester struct {
+	Query struct {
+		Amount  int     `binder:"amount,underscores"`
+		Limit   *uint64 `binder:"limit,underscores"`
+		Ratio   float64 `binder:"ratio,underscores"`
+		Amounts []int   `binder:"amounts,underscores"`
+		Plain   int     `binder:"plain"`
+	}
+}
+
+func TestQueryUnderscoresBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/?amount=1_000&limit=1_000_000&ratio=1_000.5&amounts=1_0&amounts=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryUnderscoresTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(1000, data.Query.Amount)
+		assert.Equal(getReference(uint64(1000000)), data.Query.Limit)
+		assert.Equal(1000.5, data.Query.Ratio)
+		assert.Equal([]int{10, 2}, data.Query.Amounts)
+	}
+
+	// Underscores must be between two digits, and without the option they aren't accepted at all
+	for _, query := range []string{"amount=1__0", "amount=_10", "amount=10_", "ratio=1_.5", "plain=1_000"} {
+		req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		assert.Error(c.Bind(&queryUnderscoresTester{}), query)
+	}
+}
+
+type invalidEmbeddedTester struct {
+	Header struct {
+		string
+	}
+}
+
+type invalidIndexedElementTester struct {
+	Form struct {
+		Users []struct {
+			Name  string `binder:"name"`
+			Alias string `binder:"name"`
+		} `binder:"users"`
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	binder := New()
+
+	assert.NoError(binder.ValidateSchema(&headerTester{}))
+	assert.NoError(binder.ValidateSchema(strictQueryTester{}))
+	assert.NoError(binder.ValidateSchema(&queryIndexedTester{}))
+
+	err := binder.ValidateSchema(&queryDuplicateTester{})
+	if assert.Error(err) {
+		assert.Equal("duplicate binder identifier `id` in Query", err.Error())
+	}
+
+	err = binder.ValidateSchema(&invalidEmbeddedTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "cannot have embedded fields that arent struct")
+	}
+
+	err = binder.ValidateSchema(&invalidIndexedElementTester{})
+	if assert.Error(err) {
+		assert.Equal("duplicate binder identifier `name` in Form", err.Error())
+	}
+
+	assert.Error(binder.ValidateSchema(&invalidCookieTester{}))
+	assert.Error(binder.ValidateSchema(struct{ Query struct{ Value chan int } }{}))
+	assert.Error(binder.ValidateSchema(nil))
+	assert.Error(binder.ValidateSchema("request"))
+}
+
+type defaultKindsTester struct {
+	Query struct {
+		Name    string   `binder:"name" default:"binder"`
+		Count   int64    `binder:"count" default:"-3"`
+		Size    uint16   `binder:"size" default:"512"`
+		Ratio   float32  `binder:"ratio" default:"0.5"`
+		Active  bool     `binder:"active" default:"true"`
+		Page    *int     `binder:"page" default:"1"`
+		Tags    []string `binder:"tags" default:"all"`
+		Without *int     `binder:"without"`
+	}
+
+	Header struct {
+		Retries *uint `binder:"X-Retries" default:"2"`
+	}
+}
+
+type formDefaultTester struct {
+	Form struct {
+		Title   *string `binder:"title" default:"untitled"`
+		Private bool    `binder:"private" default:"true"`
+	}
+}
+
+type optionalDefaultTester struct {
+	Query struct {
+		Address *struct {
+			City string `binder:"city"`
+			Zip  string `binder:"zip" default:"00000"`
+		}
+	}
+
+	Header struct {
+		Opt *struct {
+			Region string `binder:"X-Region"`
+			Tier   string `binder:"X-Tier" default:"free"`
+		}
+	}
+}
+
+func TestDefaultValuesKinds(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := defaultKindsTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("binder", data.Query.Name)
+		assert.Equal(int64(-3), data.Query.Count)
+		assert.Equal(uint16(512), data.Query.Size)
+		assert.Equal(float32(0.5), data.Query.Ratio)
+		assert.True(data.Query.Active)
+		assert.Equal(getReference(1), data.Query.Page)
+		assert.Equal([]string{"all"}, data.Query.Tags)
+		assert.Nil(data.Query.Without)
+		assert.Equal(getReference(uint(2)), data.Header.Retries)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("private=false"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	form := formDefaultTester{}
+	if assert.NoError(c.Bind(&form)) {
+		assert.Equal(getReference("untitled"), form.Form.Title)
+		assert.False(form.Form.Private)
+	}
+
+	// Defaults don't allocate nested pointer structures on their own, only along with a field that was sent
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	unsent := optionalDefaultTester{}
+	if assert.NoError(c.Bind(&unsent)) {
+		assert.Nil(unsent.Query.Address)
+		assert.Nil(unsent.Header.Opt)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?city=Haifa", nil)
+	req.Header.Set("X-Region", "eu")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	sent := optionalDefaultTester{}
+	if assert.NoError(c.Bind(&sent)) && assert.NotNil(sent.Query.Address) {
+		assert.Equal("Haifa", sent.Query.Address.City)
+		assert.Equal("00000", sent.Query.Address.Zip)
+	}
+
+	if assert.NotNil(sent.Header.Opt) {
+		assert.Equal("eu", sent.Header.Opt.Region)
+		assert.Equal("free", sent.Header.Opt.Tier)
+	}
+
+	// Invalid defaults fail the binding just like invalid values do
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&struct {
+		Query struct {
+			Page int `binder:"page" default:"first"`
+		}
+	}{}))
+}
+
+type headerCanonicalTester struct {
+	Header struct {
+		ContentType string
+		XRequestID  string
+		UserAgent   string `binder:"X-Agent"`
+		Accept      string
+	}
+}
+
+type headerCanonicalCollisionTester struct {
+	Header struct {
+		ContentType string
+		Type        string `binder:"content-type"`
+	}
+}
+
+func TestHeaderCanonicalIdentifiers(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("X-Request-Id", "abc")
+	req.Header.Set("User-Agent", "ignored")
+	req.Header.Set("X-Agent", "binder")
+	req.Header.Set("Accept", "*/*")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := headerCanonicalTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("text/plain", data.Header.ContentType)
+		assert.Equal("abc", data.Header.XRequestID)
+		assert.Equal("binder", data.Header.UserAgent)
+		assert.Equal("*/*", data.Header.Accept)
+	}
+
+	err := c.Bind(&headerCanonicalCollisionTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "duplicate binder identifier `Content-Type` in Header")
+	}
+}
+
+type queryRestTester struct {
+	Query struct {
+		Page    int               `binder:"page"`
+		Filters map[string]string `binder:"filter"`
+		Rest    map[string]string `binder:",rest"`
+	}
+}
+
+type queryRestSliceTester struct {
+	Query struct {
+		Page int                  `binder:"page"`
+		Rest *map[string][]string `binder:",rest"`
+	}
+}
+
+func TestQueryRestBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictQuery(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?page=2&filter[status]=open&utm_source=mail&ref=a&ref=b&Rest=x", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryRestTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(2, data.Query.Page)
+		assert.Equal(map[string]string{"status": "open"}, data.Query.Filters)
+		assert.Equal(map[string]string{"utm_source": "mail", "ref": "a", "Rest": "x"}, data.Query.Rest)
+	}
+
+	slices := queryRestSliceTester{}
+	if assert.NoError(c.Bind(&slices)) && assert.NotNil(slices.Query.Rest) {
+		assert.Equal(map[string][]string{
+			"filter[status]": {"open"},
+			"utm_source":     {"mail"},
+			"ref":            {"a", "b"},
+			"Rest":           {"x"},
+		}, *slices.Query.Rest)
+	}
+
+	// Without unknown params the rest map is left untouched
+	req = httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	data = queryRestTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Nil(data.Query.Rest)
+	}
+
+	err := c.Bind(&struct {
+		Query struct {
+			Rest map[string]int `binder:",rest"`
+		}
+	}{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "must be a `map[string]string`")
+	}
+}
+
+type queryRawTester struct {
+	Query struct {
+		Raw     url.Values        `binder:",raw"`
+		Page    int               `binder:"page"`
+		IDs     []int             `binder:"ids"`
+		Filters map[string]string `binder:"filter"`
+		Rest    map[string]string `binder:",rest"`
+	}
+}
+
+func TestQueryRawBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictQuery(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?page=2&ids=1&ids=2&filter[status]=open&utm_source=mail", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryRawTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(url.Values{
+			"page":           {"2"},
+			"ids":            {"1", "2"},
+			"filter[status]": {"open"},
+			"utm_source":     {"mail"},
+		}, data.Query.Raw)
+		assert.Equal(2, data.Query.Page)
+		assert.Equal([]int{1, 2}, data.Query.IDs)
+		assert.Equal(map[string]string{"status": "open"}, data.Query.Filters)
+		assert.Equal(map[string]string{"utm_source": "mail"}, data.Query.Rest)
+	}
+
+	// The raw field doesn't make the params known, so strict queries still reject the unknown ones
+	req = httptest.NewRequest(http.MethodGet, "/users?page=2&utm_source=mail", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&struct {
+		Query struct {
+			Raw  url.Values `binder:",raw"`
+			Page int        `binder:"page"`
+		}
+	}{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "utm_source")
+	}
+}
+
+type basicCredentials struct {
+	Username string
+	Password string
+}
+
+type headerBasicAuthTester struct {
+	Header struct {
+		Credentials basicCredentials  `binder:"Authorization,basic"`
+		Proxy       *basicCredentials `binder:"Proxy-Authorization,basic"`
+	}
+}
+
+func TestHeaderBasicAuthBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("aviv", "secret:with:colons")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := headerBasicAuthTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(basicCredentials{Username: "aviv", Password: "secret:with:colons"}, data.Header.Credentials)
+		assert.Nil(data.Header.Proxy)
+	}
+
+	malformed := map[string]string{
+		"Bearer abc":                "authorization scheme must be `Basic`",
+		"Basic not-base64!":         "credentials must be base64 encoded",
+		"Basic " + base64Of("aviv"): "credentials must be of the form `username:password`",
+	}
+
+	for header, message := range malformed {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := c.Bind(&headerBasicAuthTester{})
+		if assert.Error(err, header) && assert.IsType(&echo.HTTPError{}, err) {
+			assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+			assert.Contains(err.Error(), "malformed param `Authorization` at `Header`", header)
+			assert.Contains(err.Error(), message, header)
+		}
+	}
+
+	err := c.Bind(&struct {
+		Header struct {
+			Credentials string `binder:"Authorization,basic"`
+		}
+	}{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "must be a `struct { Username string; Password string }`")
+	}
+}
+
+func base64Of(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+type queryUnexplodedTester struct {
+	Query struct {
+		Ids      []int    `binder:"ids" explode:"false"`
+		Names    []string `binder:"names" explode:"false"`
+		Exploded []string `binder:"exploded"`
+	}
+}
+
+type formUnexplodedTester struct {
+	Form struct {
+		Tags []string `binder:"tags" explode:"false"`
+	}
+}
+
+func TestUnexplodedSliceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?ids=1,2,3&ids=4&names=a,%20b,,c&exploded=a,b", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryUnexplodedTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([]int{1, 2, 3, 4}, data.Query.Ids)
+		assert.Equal([]string{"a", "b", "c"}, data.Query.Names)
+		assert.Equal([]string{"a,b"}, data.Query.Exploded)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?ids=1,x", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&queryUnexplodedTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "query `ids[1]` must be an integer, got `x`")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("tags=go,echo&tags=binder"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	form := formUnexplodedTester{}
+	if assert.NoError(c.Bind(&form)) {
+		assert.Equal([]string{"go", "echo", "binder"}, form.Form.Tags)
+	}
+}
+
+type queryEmptyUnexplodedTester struct {
+	Query struct {
+		P   []int `binder:"p,json" explode:"false"`
+		Ids []int `binder:"ids" explode:"false"`
+	}
+}
+
+func TestEmptyUnexplodedSliceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	for _, query := range []string{"p=&ids=", "p=,&ids=,", "p=,,&ids=%20,%20"} {
+		req := httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		// Every element is empty, so the params are treated as if they weren't sent
+		data := queryEmptyUnexplodedTester{}
+		if assert.NoError(c.Bind(&data), query) {
+			assert.Nil(data.Query.P, query)
+			assert.Nil(data.Query.Ids, query)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("tags=,"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	form := formUnexplodedTester{}
+	if assert.NoError(c.Bind(&form)) {
+		assert.Nil(form.Form.Tags)
+	}
+}
+
+type queryStyleTester struct {
+	Query struct {
+		Form     []int             `binder:"form,style=form,explode=false"`
+		Exploded []string          `binder:"exploded,style=form,explode=true" explode:"false"`
+		Spaces   []string          `binder:"spaces,style=spaceDelimited"`
+		Pipes    []int             `binder:"pipes,style=pipeDelimited"`
+		Labels   map[string]string `binder:"labels,style=deepObject"`
+		Filter   struct {
+			Name string `binder:"name"`
+			Age  int    `binder:"age"`
+		} `binder:"filter,style=deepObject"`
+		Range *struct {
+			Min int `binder:"min"`
+			Max int `binder:"max"`
+		} `binder:"range,style=deepObject"`
+	}
+}
+
+type queryInvalidStyleTester struct {
+	Query struct {
+		IDs []int `binder:"ids,style=tabDelimited"`
+	}
+}
+
+func TestQueryStyleBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictQuery(true)
+	e.Binder = binder
+
+	query := url.Values{
+		"form":         {"1,2", "3"},
+		"exploded":     {"a,b"},
+		"spaces":       {"a b  c"},
+		"pipes":        {"1|2|3"},
+		"labels[env]":  {"prod"},
+		"filter[name]": {"Aviv"},
+		"filter[age]":  {"30"},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	data := queryStyleTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([]int{1, 2, 3}, data.Query.Form)
+		assert.Equal([]string{"a,b"}, data.Query.Exploded)
+		assert.Equal([]string{"a", "b", "c"}, data.Query.Spaces)
+		assert.Equal([]int{1, 2, 3}, data.Query.Pipes)
+		assert.Equal(map[string]string{"env": "prod"}, data.Query.Labels)
+		assert.Equal("Aviv", data.Query.Filter.Name)
+		assert.Equal(30, data.Query.Filter.Age)
+		assert.Nil(data.Query.Range)
+	}
+
+	// Pointers to deep objects are only allocated when one of their keys is sent
+	req = httptest.NewRequest(http.MethodGet, "/users?range[min]=1&range[max]=5", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data = queryStyleTester{}
+	if assert.NoError(c.Bind(&data)) && assert.NotNil(data.Query.Range) {
+		assert.Equal(1, data.Query.Range.Min)
+		assert.Equal(5, data.Query.Range.Max)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?pipes=1|x", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	err := c.Bind(&queryStyleTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "query `pipes[1]` must be an integer, got `x`")
+	}
+
+	// Unknown styles are a mistake in the struct definition
+	req = httptest.NewRequest(http.MethodGet, "/users?ids=1", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(c.Bind(&queryInvalidStyleTester{}), &httpError) {
+		assert.Equal(http.StatusInternalServerError, httpError.Code)
+	}
+}
+
+type bodyUnknownEmbedded struct {
+	Id int `json:"id"`
+}
+
+type bodyUnknownFieldsTester struct {
+	Body struct {
+		bodyUnknownEmbedded
+		Name    string `json:"name"`
+		Email   string
+		Address *struct {
+			City string `json:"city"`
+		} `json:"address"`
+		Meta map[string]string `json:"meta"`
+	}
+}
+
+func TestBodyUnknownFieldsBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictBody(true)
+	e.Binder = binder
+
+	bind := func(body string) error {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		return c.Bind(&bodyUnknownFieldsTester{})
+	}
+
+	assert.NoError(bind(`{"id":1,"name":"binder","EMAIL":"a@b.c","address":{"city":"Haifa"},"meta":{"any":"key"}}`))
+
+	err := bind(`{"name":"binder","age":3,"role":"admin","address":{"city":"Haifa","zip":"1"}}`)
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+		assert.Equal("unknown fields `address.zip`, `age`, `role` at `Body`", err.(*echo.HTTPError).Message)
+	}
+}
+
+type queryGroupTester struct {
+	Query struct {
+		Tags   map[string][]string `binder:"tag.,group"`
+		Labels *map[string]string  `binder:"label.,group"`
+		Page   int                 `binder:"page"`
+	}
+}
+
+func TestQueryGroupBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictQuery(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?tag.a=1&tag.a=2&tag.b=3&label.env=prod&page=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryGroupTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(map[string][]string{"a": {"1", "2"}, "b": {"3"}}, data.Query.Tags)
+		if assert.NotNil(data.Query.Labels) {
+			assert.Equal(map[string]string{"env": "prod"}, *data.Query.Labels)
+		}
+		assert.Equal(2, data.Query.Page)
+	}
+
+	// The prefix alone isn't a member of the group
+	req = httptest.NewRequest(http.MethodGet, "/users?tag.=1", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryGroupTester{}))
+
+	req = httptest.NewRequest(http.MethodGet, "/users?tag.a=1", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&struct {
+		Query struct {
+			Tags map[string]int `binder:"tag.,group"`
+		}
+	}{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "must be a `map[string]string`")
+	}
+}
+
+func TestBindSectionInto(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1?page=2&sort=desc", nil)
+	req.Header.Set("X-Version", "v2")
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "abc"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	path := struct {
+		Id int `binder:"id"`
+	}{}
+	if assert.NoError(binder.BindPathInto(c, &path)) {
+		assert.Equal(1, path.Id)
+	}
+
+	// The section isn't validated, only bound
+	query := struct {
+		Page  int    `binder:"page"`
+		Sort  string `binder:"sort"`
+		Limit int    `binder:"limit" validate:"required"`
+	}{}
+	if assert.NoError(binder.BindQueryInto(c, &query)) {
+		assert.Equal(2, query.Page)
+		assert.Equal("desc", query.Sort)
+	}
+
+	header := struct {
+		Version string `binder:"X-Version"`
+	}{}
+	if assert.NoError(binder.BindHeaderInto(c, &header)) {
+		assert.Equal("v2", header.Version)
+	}
+
+	cookie := struct {
+		Session string `binder:"session_id"`
+	}{}
+	if assert.NoError(binder.BindCookieInto(c, &cookie)) {
+		assert.Equal("abc", cookie.Session)
+	}
+
+	// The same errors as the full binding are returned
+	err := binder.BindQueryInto(c, &struct {
+		Page bool `binder:"page"`
+	}{})
+	if assert.Error(err) && assert.IsType(&echo.HTTPError{}, err) {
+		assert.Equal(http.StatusBadRequest, err.(*echo.HTTPError).Code)
+	}
+
+	err = binder.BindFormInto(c, &struct{}{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "unsupported http method `GET`")
+	}
+
+	for _, dst := range []interface{}{nil, query, new(int), (*struct{})(nil)} {
+		err := binder.BindQueryInto(c, dst)
+		if assert.Error(err) {
+			assert.Equal(badRequestError(errorInvalidType).Error(), err.Error())
+		}
+	}
+}
+
+type nestedQueryState struct {
+	A    int      `binder:"a"`
+	B    int      `binder:"b"`
+	Tags []string `binder:"tags"`
+}
+
+type queryNestedTester struct {
+	Query struct {
+		State    nestedQueryState  `binder:"state,nested-query"`
+		Previous *nestedQueryState `binder:"previous,nested-query"`
+		Missing  *nestedQueryState `binder:"missing,nested-query"`
+		Page     int               `binder:"page"`
+	}
+}
+
+func TestQueryNestedQueryBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	state := url.Values{"a": {"1"}, "b": {"2"}, "tags": {"x", "y"}}
+	query := url.Values{"state": {state.Encode()}, "previous": {"a=3"}, "page": {"4"}}
+
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := queryNestedTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(nestedQueryState{A: 1, B: 2, Tags: []string{"x", "y"}}, data.Query.State)
+		assert.Equal(&nestedQueryState{A: 3}, data.Query.Previous)
+		assert.Nil(data.Query.Missing)
+		assert.Equal(4, data.Query.Page)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?state=a%3Dx", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	assert.Error(c.Bind(&queryNestedTester{}))
+
+	req = httptest.NewRequest(http.MethodGet, "/users?state=a%3D%25zz", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&queryNestedTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "malformed param `state` at `Query`")
+	}
+}
+
+func getReference[T any](data T) *T {
+	return &data
+}
+
+type genericListRequest[T any] struct {
+	Query struct {
+		Filter T   `binder:"filter"`
+		Page   int `binder:"page"`
+	}
+
+	Form struct {
+		Values []T `binder:"values"`
+	}
+}
+
+type genericFilter struct {
+	Status string `binder:"status"`
+	Owner  *int   `binder:"owner"`
+}
+
+func TestGenericRequestBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	form := url.Values{"values": {"1", "2"}}
+	req := httptest.NewRequest(http.MethodPost, "/users?filter=3&page=2", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	scalar := genericListRequest[int]{}
+	if assert.NoError(c.Bind(&scalar)) {
+		assert.Equal(3, scalar.Query.Filter)
+		assert.Equal(2, scalar.Query.Page)
+		assert.Equal([]int{1, 2}, scalar.Form.Values)
+	}
+	assert.NoError(binder.ValidateSchema(&scalar))
+
+	// A struct type argument is walked like any other nested struct
+	req = httptest.NewRequest(http.MethodPost, "/users?status=open&owner=7&page=1&filter=all", strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	nested := genericListRequest[genericFilter]{}
+	if assert.NoError(c.Bind(&nested)) {
+		assert.Equal("open", nested.Query.Filter.Status)
+		assert.Equal(getReference(7), nested.Query.Filter.Owner)
+		assert.Equal(1, nested.Query.Page)
+	}
+
+	// The instantiations don't share their cached schemas
+	typed, err := binder.BindType(reflect.TypeOf(genericListRequest[string]{}), c)
+	if assert.NoError(err) {
+		assert.Equal("all", typed.(*genericListRequest[string]).Query.Filter)
+	}
+}
+
+type embeddedHeader struct {
+	Omer string `binder:"harari"`
+}
+
+type AnotherEmbeddedHeader struct {
+	Yaeli string `binder:"yerushalmi"`
+}
+
+type headerTester struct {
+	Header struct {
+		embeddedHeader
+		*AnotherEmbeddedHeader
+		Name  string
+		Build int `binder:"custom"`
+	}
+}
+
+type headerEmbbeddedFieldTester struct {
+	Header struct {
+		string
+	}
+}
+
+type headerEmbeddedInterfaceTester struct {
+	Header struct {
+		fmt.Stringer
+		Name string
+	}
+}
+
+type namedStringer string
+
+func (s namedStringer) String() string {
+	return string(s)
+}
+
+func TestHeaderEmbeddedInterfaceBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Name", "Omri")
+	req.Header.Set("Stringer", "ignored")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	// The embedded interface that was set before the binding is left as is
+	data := headerEmbeddedInterfaceTester{}
+	data.Header.Stringer = namedStringer("preset")
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Omri", data.Header.Name)
+		assert.Equal("preset", data.Header.String())
+	}
+	assert.NoError(binder.ValidateSchema(&data))
+
+	// And a nil one stays nil
+	data = headerEmbeddedInterfaceTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Nil(data.Header.Stringer)
+	}
+}
+
+func TestHeaderBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.CallEchoDefaultBinderOnError(true)
+	e.Binder = binder
+
+	// Test normal header binding
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Set("Name", "Omri")
+	c.Request().Header.Set("Custom", "132")
+	c.Request().Header.Set("harari", "1234")
+	c.Request().Header.Set("yerushalmi", "0525381648")
+
+	normal := new(headerTester)
+	err := c.Bind(normal)
+	if assert.NoError(err) {
+		assert.Equal("Omri", normal.Header.Name)
+		assert.Equal(132, normal.Header.Build)
+		assert.Equal("1234", normal.Header.embeddedHeader.Omer)
+		assert.Equal("0525381648", normal.Header.AnotherEmbeddedHeader.Yaeli)
+	}
+
+	// Test invalid embedded type binding
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	embedded := new(headerEmbbeddedFieldTester)
+	err = c.Bind(embedded)
+	assert.Error(err)
+}
+
+type headerIgnoreTester struct {
+	Header struct {
+		embeddedHeader
+		*AnotherEmbeddedHeader
+		Name  string
+		Build int     `binder:"custom"`
+		Koren string  `binder:"koren"`
+		Yael  *string `binder:"abergel"`
+		Eden  int     `binder:"eden"`
+		Lia   *int    `binder:"lia"`
+	}
+}
+
+func TestIgnoreNullStringOnHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.CallEchoDefaultBinderOnError(true)
+	binder.IgnoreNullStringOnHeader(true)
+	e.Binder = binder
+
+	// Test normal header binding
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Set("Name", "Omri")
+	c.Request().Header.Set("Custom", "132")
+	c.Request().Header.Set("harari", "1234")
+	c.Request().Header.Set("yerushalmi", "0525381648")
+	c.Request().Header.Set("koren", "null")
+	c.Request().Header.Set("abergel", "null")
+	c.Request().Header.Set("eden", "null")
+	c.Request().Header.Set("lia", "null")
+
+	normal := new(headerIgnoreTester)
+	err := c.Bind(normal)
+	if assert.NoError(err) {
+		assert.Equal("Omri", normal.Header.Name)
+		assert.Equal(132, normal.Header.Build)
+		assert.Equal("1234", normal.Header.embeddedHeader.Omer)
+		assert.Equal("0525381648", normal.Header.AnotherEmbeddedHeader.Yaeli)
+		assert.Equal("", normal.Header.Koren)
+		assert.Equal((*string)(nil), normal.Header.Yael)
+		assert.Equal(0, normal.Header.Eden)
+		assert.Equal((*int)(nil), normal.Header.Lia)
+	}
+
+	// Test invalid embedded type binding
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	embedded := new(headerEmbbeddedFieldTester)
+	err = c.Bind(embedded)
+	assert.Error(err)
+}
+
+type headerQValuesTester struct {
+	Header struct {
+		Languages []string `binder:"Accept-Language,qvalues"`
+		Encoding  string   `binder:"Accept-Encoding,qvalues"`
+	}
+}
+
+func TestHeaderQValuesBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// Values are ordered by descending quality, and ones without a quality are the most preferred
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Set("Accept-Language", "en;q=0.8, fr;q=0.9, he, de;q=0")
+	c.Request().Header.Set("Accept-Encoding", "gzip;q=0.5, br")
+
+	normal := new(headerQValuesTester)
+	err := c.Bind(normal)
+	if assert.NoError(err) {
+		assert.Equal([]string{"he", "fr", "en"}, normal.Header.Languages)
+		assert.Equal("br", normal.Header.Encoding)
+	}
+
+	// Malformed quality values should fail the binding
+	for _, value := range []string{"en;q=abc", "en;q=1.5", "en;q=-1"} {
+		req = httptest.NewRequest(http.MethodGet, "/users", nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.Request().Header.Set("Accept-Language", value)
+
+		err = c.Bind(new(headerQValuesTester))
+		assert.Error(err, value)
+	}
+}
+
+type headerETagsTester struct {
+	Header struct {
+		IfNoneMatch []ETag   `binder:"If-None-Match,etags"`
+		IfMatch     []string `binder:"If-Match,etags"`
+		Missing     []ETag   `binder:"X-Missing,etags"`
+	}
+}
+
+func TestHeaderETagsBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Add("If-None-Match", `"a1", W/"b2"`)
+	c.Request().Header.Add("If-None-Match", `""`)
+	c.Request().Header.Set("If-Match", `W/"c3", *`)
+
+	data := new(headerETagsTester)
+	if assert.NoError(c.Bind(data)) {
+		assert.Equal([]ETag{{Value: "a1"}, {Value: "b2", Weak: true}, {Value: ""}}, data.Header.IfNoneMatch)
+		assert.Equal([]string{"c3", "*"}, data.Header.IfMatch)
+		assert.Nil(data.Header.Missing)
+	}
+
+	// Unquoted tags should fail the binding
+	for _, value := range []string{`a1`, `W/a1`, `"a1`, `"a"1"`} {
+		req = httptest.NewRequest(http.MethodGet, "/users", nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.Request().Header.Set("If-None-Match", value)
+
+		assert.Error(c.Bind(new(headerETagsTester)), value)
+	}
+}
+
+type headerByteRangeTester struct {
+	Header struct {
+		Ranges []ByteRange `binder:"Range,byterange"`
+	}
+}
+
+func TestHeaderByteRangeBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	newContext := func(value string) echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/files/video.mp4", nil)
+		if value != "" {
+			req.Header.Set("Range", value)
+		}
+
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	for value, expected := range map[string][]ByteRange{
+		"bytes=0-1023":            {{Start: 0, End: 1023}},
+		"bytes=0-99, 200-299,,5-": {{Start: 0, End: 99}, {Start: 200, End: 299}, {Start: 5, End: -1}},
+		"bytes=500-":              {{Start: 500, End: -1}},
+		"Bytes=-500":              {{Start: -1, End: 500}},
+	} {
+		data := new(headerByteRangeTester)
+		if assert.NoError(newContext(value).Bind(data), value) {
+			assert.Equal(expected, data.Header.Ranges, value)
+		}
+	}
+
+	// Missing headers leave the field untouched
+	data := new(headerByteRangeTester)
+	if assert.NoError(newContext("").Bind(data)) {
+		assert.Nil(data.Header.Ranges)
+	}
+
+	// A single range can be bound into a ByteRange field, but not multiple ones
+	single := new(struct {
+		Header struct {
+			Range ByteRange `binder:"Range,byterange"`
+		}
+	})
+	if assert.NoError(newContext("bytes=10-20").Bind(single)) {
+		assert.Equal(ByteRange{Start: 10, End: 20}, single.Header.Range)
+	}
+
+	pointer := new(struct {
+		Header struct {
+			Range *ByteRange `binder:"Range,byterange"`
+		}
+	})
+	if assert.NoError(newContext("bytes=-5").Bind(pointer)) {
+		assert.Equal(&ByteRange{Start: -1, End: 5}, pointer.Header.Range)
+	}
+
+	assert.Error(newContext("bytes=0-1,3-4").Bind(single))
+
+	for _, value := range []string{"0-1023", "items=0-5", "bytes=", "bytes=-", "bytes=5", "bytes=10-5", "bytes=a-b", "bytes=-1-2", "bytes=+1-2"} {
+		err := newContext(value).Bind(new(headerByteRangeTester))
+		if assert.Error(err, value) {
+			assert.Contains(err.Error(), "malformed param `Range` at `Header`", value)
+		}
+	}
+}
+
+type headerGenerateTester struct {
+	Header struct {
+		RequestId     string `binder:"X-Request-Id,generate=uuid"`
+		CorrelationId string `binder:"X-Correlation-Id,generate=sequence"`
+	}
+}
+
+func TestHeaderGenerateBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.RegisterGenerator("sequence", func() (string, error) { return "42", nil })
+	e.Binder = binder
+
+	// Present headers are bound as is
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Set("X-Request-Id", "request")
+	c.Request().Header.Set("X-Correlation-Id", "correlation")
+
+	present := new(headerGenerateTester)
+	err := c.Bind(present)
+	if assert.NoError(err) {
+		assert.Equal("request", present.Header.RequestId)
+		assert.Equal("correlation", present.Header.CorrelationId)
+		assert.Equal("", rec.Header().Get("X-Request-Id"))
+	}
+
+	// Absent headers are generated, and written to the response if configured
+	binder.WriteGeneratedHeaders(true)
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	absent := new(headerGenerateTester)
+	err = c.Bind(absent)
+	if assert.NoError(err) {
+		assert.Regexp("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$", absent.Header.RequestId)
+		assert.Equal("42", absent.Header.CorrelationId)
+		assert.Equal(absent.Header.RequestId, rec.Header().Get("X-Request-Id"))
+		assert.Equal("42", rec.Header().Get("X-Correlation-Id"))
+	}
+
+	// Unknown generators should fail
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err = c.Bind(new(struct {
+		Header struct {
+			Id string `binder:"X-Id,generate=unknown"`
+		}
+	}))
+	assert.Error(err)
+}
+
+type headerIndexedTester struct {
+	Header struct {
+		Items []string `binder:"X-Item-,indexed"`
+		Ids   []int    `binder:"X-Id-,indexed"`
+	}
+}
+
+func TestHeaderIndexedBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Set("X-Item-10", "c")
+	c.Request().Header.Set("X-Item-2", "b")
+	c.Request().Header.Set("X-Item-1", "a")
+	c.Request().Header.Set("X-Item-Other", "ignored")
+	c.Request().Header.Set("x-id-2", "20")
+	c.Request().Header.Set("x-id-1", "10")
+
+	u := new(headerIndexedTester)
+	err := c.Bind(u)
+	if assert.NoError(err) {
+		assert.Equal([]string{"a", "b", "c"}, u.Header.Items)
+		assert.Equal([]int{10, 20}, u.Header.Ids)
+	}
+
+	// Without any matching header the slice stays nil
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	empty := new(headerIndexedTester)
+	err = c.Bind(empty)
+	if assert.NoError(err) {
+		assert.Nil(empty.Header.Items)
+	}
+}
+
+func TestLowercaseHeaderLookup(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// The header map is not normalized, so the canonical lookup can't find the values
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header = http.Header{"name": {"Omri"}, "custom": {"132"}}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	canonical := new(headerTester)
+	err := c.Bind(canonical)
+	if assert.NoError(err) {
+		assert.Equal("", canonical.Header.Name)
+		assert.Equal(0, canonical.Header.Build)
+	}
+
+	binder.SetLowercaseHeaderLookup(true)
+
+	lowercase := new(headerTester)
+	err = c.Bind(lowercase)
+	if assert.NoError(err) {
+		assert.Equal("Omri", lowercase.Header.Name)
+		assert.Equal(132, lowercase.Header.Build)
+	}
+}
+
+type headerSignatureTester struct {
+	Body struct {
+		Name string `json:"name"`
+	}
+
+	Header struct {
+		Signature string `binder:"X-Signature,hmac"`
+	}
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHeaderSignatureBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	body := `{"name":"Omri"}`
+	newContext := func(body, signature string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if signature != "" {
+			req.Header.Set("X-Signature", signature)
+		}
+
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	// Without a secret the signature can't be verified
+	err := newContext(body, sign("secret", body)).Bind(new(headerSignatureTester))
+	assert.Error(err)
+
+	binder.SetSignatureSecret([]byte("secret"))
+
+	// The body is read before the header, and the signature is still verified against it
+	valid := new(headerSignatureTester)
+	err = newContext(body, sign("secret", body)).Bind(valid)
+	if assert.NoError(err) {
+		assert.Equal("Omri", valid.Body.Name)
+		assert.Equal(sign("secret", body), valid.Header.Signature)
+	}
+
+	// Tampered bodies, wrong secrets and missing signatures should fail
+	err = newContext(`{"name":"Koren"}`, sign("secret", body)).Bind(new(headerSignatureTester))
+	assert.Error(err)
+
+	err = newContext(body, sign("other", body)).Bind(new(headerSignatureTester))
+	assert.Error(err)
+
+	err = newContext(body, "").Bind(new(headerSignatureTester))
+	assert.Error(err)
+}
+
+type headerDecryptTester struct {
+	Header struct {
+		Token   string `binder:"X-Token,decrypt"`
+		UserID  int    `binder:"X-User-Id,decrypt"`
+		Version string `binder:"X-Version,decrypt" default:"v1"`
+	}
+}
+
+func TestHeaderDecryptBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	newContext := func() echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set("X-Token", base64.StdEncoding.EncodeToString([]byte("secret-token")))
+		req.Header.Set("X-User-Id", base64.StdEncoding.EncodeToString([]byte("42")))
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	// Without a decryptor the headers can't be decrypted
+	err := newContext().Bind(new(headerDecryptTester))
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusInternalServerError, httpError.Code)
+	}
+
+	decrypted := []string{}
+	binder.SetHeaderDecryptor(func(field, value string) (string, error) {
+		decrypted = append(decrypted, field)
+		plain, err := base64.StdEncoding.DecodeString(value)
+		return string(plain), err
+	})
+
+	// The decrypted values are parsed by the kind of the field, and defaults are not decrypted
+	data := new(headerDecryptTester)
+	if assert.NoError(newContext().Bind(data)) {
+		assert.Equal("secret-token", data.Header.Token)
+		assert.Equal(42, data.Header.UserID)
+		assert.Equal("v1", data.Header.Version)
+		assert.ElementsMatch([]string{"X-Token", "X-User-Id"}, decrypted)
+	}
+
+	// A failure of the decryption is the client's fault
+	c := newContext()
+	c.Request().Header.Set("X-Token", "not base64!")
+	err = c.Bind(new(headerDecryptTester))
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusBadRequest, httpError.Code)
+		assert.Contains(err.Error(), "failed to decrypt param `X-Token` at `Header`")
+	}
+}
+
+type formTester struct {
+	Form struct {
+		Name string
+		Age  int       `binder:"custom"`
+		Data []float64 `binder:"data"`
+	}
+}
+
+type formEmbeddedTester struct {
+	Form struct {
+		string
+	}
+}
+
+type formValidEmbeddedTester struct {
+	Form struct {
+		validEmbedded
+	}
+}
+
+func TestFormBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.CallEchoDefaultBinderOnError(true)
+	e.Binder = binder
+
+	// Validate normal form binding
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("Name=Koren&custom=15&data=3.14157&data=152.32&Data=0"))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	normal := new(formTester)
+	err := c.Bind(normal)
+	if assert.NoError(err) {
+		assert.Equal("Koren", normal.Form.Name)
+		assert.Equal(15, normal.Form.Age)
+		assert.Equal([]float64{3.14157, 152.32}, normal.Form.Data)
+	}
+
+	// Validate embedded form binding
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("Name=Koren&custom=15&data=3.14157&data=152.32&Data=0"))
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	embedded := new(formEmbeddedTester)
+	err = c.Bind(embedded)
+	assert.Error(err)
+
+	// Validate valid embedded form binding
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("Name=Koren&custom=15&data=3.14157&data=152.32&Data=0&key1=value1&key2=2"))
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	validEmbedded := new(formValidEmbeddedTester)
+	err = c.Bind(validEmbedded)
+	if assert.NoError(err) {
+		assert.Equal("value1", validEmbedded.Form.Key1)
+		assert.Equal(2, validEmbedded.Form.Key2)
+	}
+}
+
+type formPayload struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+type formJSONTester struct {
+	Form struct {
+		Payload formPayload `binder:"payload,json"`
+		Title   string      `binder:"title"`
+	}
+
+	File struct {
+		Avatar *multipart.FileHeader   `binder:"avatar"`
+		Extras []*multipart.FileHeader `binder:"extras"`
+	}
+}
+
+func TestFormJSONPartBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	assert.NoError(writer.WriteField("payload", `{"name":"Koren","tags":["a","b"]}`))
+	assert.NoError(writer.WriteField("title", "Profile"))
+
+	part, err := writer.CreateFormFile("avatar", "avatar.png")
+	assert.NoError(err)
+	_, err = part.Write([]byte("image"))
+	assert.NoError(err)
+	assert.NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(formJSONTester)
+	err = c.Bind(u)
+	if assert.NoError(err) {
+		assert.Equal("Koren", u.Form.Payload.Name)
+		assert.Equal([]string{"a", "b"}, u.Form.Payload.Tags)
+		assert.Equal("Profile", u.Form.Title)
+		if assert.NotNil(u.File.Avatar) {
+			assert.Equal("avatar.png", u.File.Avatar.Filename)
+			assert.Equal(int64(5), u.File.Avatar.Size)
+		}
+		assert.Nil(u.File.Extras)
+	}
+
+	// Malformed JSON in the part should fail the binding
+	body = new(bytes.Buffer)
+	writer = multipart.NewWriter(body)
+	assert.NoError(writer.WriteField("payload", `{"name":`))
+	assert.NoError(writer.Close())
+
+	req = httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err = c.Bind(new(formJSONTester))
+	assert.Error(err)
+}
+
+type formFileTester struct {
+	Form struct {
+		Title   string                  `binder:"title"`
+		Avatar  *multipart.FileHeader   `binder:"avatar"`
+		Missing *multipart.FileHeader   `binder:"missing"`
+		Extras  []*multipart.FileHeader `binder:"extras"`
+	}
+}
+
+func writeFormFile(writer *multipart.Writer, name, filename, content string) error {
+	part, err := writer.CreateFormFile(name, filename)
+	if err != nil {
+		return err
+	}
+
+	_, err = part.Write([]byte(content))
+	return err
+}
+
+type multipartFormTester struct {
+	Form struct {
+		Title string          `binder:"title"`
+		Raw   *multipart.Form `binder:"raw"`
+	}
+
+	File struct {
+		Avatar *multipart.FileHeader `binder:"avatar"`
+		All    *multipart.Form
+	}
+}
+
+func TestMultipartFormBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	assert.NoError(writer.WriteField("title", "Profile"))
+	assert.NoError(writer.WriteField("raw", "not bound"))
+	assert.NoError(writeFormFile(writer, "avatar", "avatar.png", "image"))
+	assert.NoError(writeFormFile(writer, "extras", "a.txt", "a"))
+	assert.NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	// The whole parsed form is set into both of the sections, next to the other bound fields
+	data := multipartFormTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Profile", data.Form.Title)
+		if assert.NotNil(data.File.Avatar) {
+			assert.Equal("avatar.png", data.File.Avatar.Filename)
+		}
+
+		for _, form := range []*multipart.Form{data.Form.Raw, data.File.All} {
+			if assert.NotNil(form) {
+				assert.Equal([]string{"Profile"}, form.Value["title"])
+				assert.Equal([]string{"not bound"}, form.Value["raw"])
+				assert.Len(form.File["avatar"], 1)
+				assert.Len(form.File["extras"], 1)
+			}
+		}
+	}
+
+	// Forms that aren't multipart leave the field untouched
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("title=Profile"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data = multipartFormTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Profile", data.Form.Title)
+		assert.Nil(data.Form.Raw)
+	}
+}
+
+func TestFormFileBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.StrictForm(true)
+	e.Binder = binder
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	assert.NoError(writer.WriteField("title", "Profile"))
+	assert.NoError(writeFormFile(writer, "avatar", "avatar.png", "image"))
+	assert.NoError(writeFormFile(writer, "extras", "a.txt", "a"))
+	assert.NoError(writeFormFile(writer, "extras", "b.txt", "bb"))
+	assert.NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := formFileTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Profile", data.Form.Title)
+		if assert.NotNil(data.Form.Avatar) {
+			assert.Equal("avatar.png", data.Form.Avatar.Filename)
+			assert.Equal(int64(5), data.Form.Avatar.Size)
+		}
+		assert.Nil(data.Form.Missing)
+		if assert.Len(data.Form.Extras, 2) {
+			assert.Equal("a.txt", data.Form.Extras[0].Filename)
+			assert.Equal("b.txt", data.Form.Extras[1].Filename)
+		}
+	}
+
+	// A single file field can't receive more than one file
+	body = new(bytes.Buffer)
+	writer = multipart.NewWriter(body)
+	assert.NoError(writeFormFile(writer, "avatar", "a.png", "a"))
+	assert.NoError(writeFormFile(writer, "avatar", "b.png", "b"))
+	assert.NoError(writer.Close())
+
+	req = httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&formFileTester{})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "expected a single file for `avatar` at `Form`, got 2")
+	}
+}
+
+type fileContentTester struct {
+	File struct {
+		Avatar []byte    `binder:"avatar"`
+		Upload io.Reader `binder:"upload"`
+	}
+}
+
+func TestFileContentBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.SetMaxFileSize(8)
+	e.Binder = binder
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	assert.NoError(writeFormFile(writer, "avatar", "avatar.png", "image"))
+	assert.NoError(writeFormFile(writer, "upload", "data.csv", "a,b,c\n1,2,3"))
+	assert.NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := fileContentTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal([]byte("image"), data.File.Avatar)
+
+		// The size limit only applies to the files that are read into memory
+		if assert.NotNil(data.File.Upload) {
+			content, err := io.ReadAll(data.File.Upload)
+			assert.NoError(err)
+			assert.Equal("a,b,c\n1,2,3", string(content))
+		}
+	}
+
+	// Files larger than the limit fail the binding
+	body = new(bytes.Buffer)
+	writer = multipart.NewWriter(body)
+	assert.NoError(writeFormFile(writer, "avatar", "avatar.png", "a large image"))
+	assert.NoError(writer.Close())
+
+	req = httptest.NewRequest(http.MethodPost, "/users", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&fileContentTester{})
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusRequestEntityTooLarge, httpError.Code)
+		assert.Contains(err.Error(), "file `avatar` at `File` is larger than 8 bytes")
+	}
+}
+
+type formAddress struct {
+	City string `binder:"city" validate:"required"`
+	Zip  string `binder:"zip"`
+}
+
+type formDottedTester struct {
+	Form struct {
+		Name    string       `binder:"name"`
+		Address formAddress  `binder:"address"`
+		Billing *formAddress `binder:"billing"`
+		Contact struct {
+			Phone string `binder:"phone"`
+		}
+	}
+}
+
+func TestFormDottedKeysBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.DottedFormKeys(true)
+	binder.StrictForm(true)
+	e.Binder = binder
+
+	form := url.Values{"name": {"Omri"}, "address.city": {"Haifa"}, "address.zip": {"31000"}, "Contact.phone": {"123"}}
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	data := formDottedTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Omri", data.Form.Name)
+		assert.Equal(formAddress{City: "Haifa", Zip: "31000"}, data.Form.Address)
+		assert.Nil(data.Form.Billing)
+		assert.Equal("123", data.Form.Contact.Phone)
+	}
+
+	// The validation errors report the nested fields by their dotted keys
+	form = url.Values{"name": {"Omri"}, "address.zip": {"31000"}}
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	err := c.Bind(&formDottedTester{})
+	validationErrors := validator.ValidationErrors{}
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("formDottedTester.Form.address.city", validationErrors[0].Namespace())
+		assert.Contains(err.Error(), "Form.address.city")
+	}
+
+	// Without the flag the nested fields are bound by their own identifiers, so the same structure can't be nested twice
+	form = url.Values{"city": {"Haifa"}}
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	flat := struct {
+		Form struct {
+			Address formAddress `binder:"address"`
+		}
+	}{}
+	if assert.NoError(New().Bind(&flat, c)) {
+		assert.Equal("Haifa", flat.Form.Address.City)
+	}
+
+	err = New().Bind(&formDottedTester{}, c)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "duplicate binder identifier `city`")
+	}
+}
+
+type queryDottedTester struct {
+	Query struct {
+		Name    string       `binder:"name"`
+		Address formAddress  `binder:"address"`
+		Billing *formAddress `binder:"billing"`
+		Users   []struct {
+			Name string `binder:"name"`
+		} `binder:"users"`
+	}
+}
+
+func TestQueryDottedKeysBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.DottedQueryKeys(true)
+	e.Binder = binder
+
+	query := url.Values{"name": {"Omri"}, "address.city": {"NYC"}, "address.zip": {"10001"}, "billing.city": {"Haifa"}, "users[0].name": {"Koren"}}
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// The nested structures of the same type don't collide, and the elements of indexed slices are still bound
+	data := queryDottedTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Omri", data.Query.Name)
+		assert.Equal(formAddress{City: "NYC", Zip: "10001"}, data.Query.Address)
+		assert.Equal(&formAddress{City: "Haifa"}, data.Query.Billing)
+		if assert.Len(data.Query.Users, 1) {
+			assert.Equal("Koren", data.Query.Users[0].Name)
+		}
+	}
+
+	// The flag of the query doesn't affect the form, and the default stays flat
+	assert.Error(New().ValidateSchema(&queryDottedTester{}))
+	assert.NoError(binder.ValidateSchema(&queryDottedTester{}))
+	assert.Error(binder.ValidateSchema(&struct {
+		Form struct {
+			Address formAddress  `binder:"address"`
+			Billing *formAddress `binder:"billing"`
+		}
+	}{}))
+}
+
+type validateTester struct {
+	Header struct {
+		Name    string `validate:"required"`
+		Version string
+	}
+}
+
+func TestValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.CallEchoDefaultBinderOnError(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Request().Header.Set("Name", "Omri")
+	c.Request().Header.Set("Version", "132")
+
+	normal := new(validateTester)
+	err := c.Bind(normal)
+	if assert.NoError(err) {
+		assert.Equal("Omri", normal.Header.Name)
+		assert.Equal("132", normal.Header.Version)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.Request().Header.Set("Version", "132")
+
+	normal = new(validateTester)
+	err = c.Bind(normal)
 	assert.Error(err)
 }
 
-type headerIgnoreTester struct {
+type defaultRequiredTester struct {
+	Query struct {
+		Sort  string `binder:"sort" default:"asc" validate:"required"`
+		Limit int    `binder:"limit" default:"20" validate:"required,min=1"`
+	}
+
+	Header struct {
+		Version string `binder:"X-Version" default:"v1" validate:"required"`
+	}
+}
+
+type defaultFuncTester struct {
+	Query struct {
+		Since time.Time `binder:"since" validate:"required"`
+		Sort  string    `binder:"sort" default:"asc"`
+	}
+
 	Header struct {
-		embeddedHeader
-		*AnotherEmbeddedHeader
-		Name  string
-		Build int     `binder:"custom"`
-		Koren string  `binder:"koren"`
-		Yael  *string `binder:"abergel"`
-		Eden  int     `binder:"eden"`
-		Lia   *int    `binder:"lia"`
+		Version string `binder:"X-Version" default:"v1"`
 	}
 }
 
-func TestIgnoreNullStringOnHeader(t *testing.T) {
+func TestDefaultFunc(t *testing.T) {
 	assert := assert.New(t)
 
 	e := echo.New()
 	binder := New()
-	binder.CallEchoDefaultBinderOnError(true)
-	binder.IgnoreNullStringOnHeader(true)
 	e.Binder = binder
 
-	// Test normal header binding
+	now := time.Now().UTC().Truncate(time.Second)
+	binder.SetDefaultFunc("Query.since", func(c echo.Context) (string, error) {
+		return now.Add(-24 * time.Hour).Format(time.RFC3339), nil
+	})
+	binder.SetDefaultFunc("Query.sort", func(c echo.Context) (string, error) {
+		return c.Request().Header.Get("X-Default-Sort"), nil
+	})
+	binder.SetDefaultFunc("Header.X-Version", func(c echo.Context) (string, error) {
+		return "v3", nil
+	})
+
 	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Default-Sort", "desc")
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	c.Request().Header.Set("Name", "Omri")
-	c.Request().Header.Set("Custom", "132")
-	c.Request().Header.Set("harari", "1234")
-	c.Request().Header.Set("yerushalmi", "0525381648")
-	c.Request().Header.Set("koren", "null")
-	c.Request().Header.Set("abergel", "null")
-	c.Request().Header.Set("eden", "null")
-	c.Request().Header.Set("lia", "null")
 
-	normal := new(headerIgnoreTester)
-	err := c.Bind(normal)
+	// The computed defaults are parsed like sent values, and take precedence over the tag
+	data := defaultFuncTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.True(now.Add(-24 * time.Hour).Equal(data.Query.Since))
+		assert.Equal("desc", data.Query.Sort)
+		assert.Equal("v3", data.Header.Version)
+	}
+
+	// Sent values are never replaced by the computed defaults
+	req = httptest.NewRequest(http.MethodGet, "/users?since=2022-01-02T03:04:05Z&sort=name", nil)
+	req.Header.Set("X-Version", "v2")
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data = defaultFuncTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC), data.Query.Since)
+		assert.Equal("name", data.Query.Sort)
+		assert.Equal("v2", data.Header.Version)
+	}
+
+	// A failure to compute the default is an internal error
+	binder.SetDefaultFunc("Query.since", func(c echo.Context) (string, error) {
+		return "", errors.New("clock is unavailable")
+	})
+
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/users", nil), httptest.NewRecorder())
+	err := c.Bind(&defaultFuncTester{})
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusInternalServerError, httpError.Code)
+	}
+}
+
+func TestDefaultBeforeValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// Nothing is sent, so the required fields are only filled by their defaults
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(defaultRequiredTester)
+	err := c.Bind(u)
 	if assert.NoError(err) {
-		assert.Equal("Omri", normal.Header.Name)
-		assert.Equal(132, normal.Header.Build)
-		assert.Equal("1234", normal.Header.embeddedHeader.Omer)
-		assert.Equal("0525381648", normal.Header.AnotherEmbeddedHeader.Yaeli)
-		assert.Equal("", normal.Header.Koren)
-		assert.Equal((*string)(nil), normal.Header.Yael)
-		assert.Equal(0, normal.Header.Eden)
-		assert.Equal((*int)(nil), normal.Header.Lia)
+		assert.Equal("asc", u.Query.Sort)
+		assert.Equal(20, u.Query.Limit)
+		assert.Equal("v1", u.Header.Version)
 	}
 
-	// Test invalid embedded type binding
-	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	// Sent values take precedence over the defaults, and are still validated
+	req = httptest.NewRequest(http.MethodGet, "/users?sort=desc&limit=0", nil)
 	rec = httptest.NewRecorder()
 	c = e.NewContext(req, rec)
+	c.Request().Header.Set("X-Version", "v2")
 
-	embedded := new(headerEmbbeddedFieldTester)
-	err = c.Bind(embedded)
+	u = new(defaultRequiredTester)
+	err = c.Bind(u)
 	assert.Error(err)
+	assert.Equal("desc", u.Query.Sort)
+	assert.Equal("v2", u.Header.Version)
+}
+
+func TestValidatorInlineSectionNames(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?page=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	u := new(struct {
+		Query struct {
+			Page   int    `binder:"page" validate:"required"`
+			SortBy string `binder:"sort_by" validate:"required"`
+			Limit  int    `validate:"max=100"`
+		}
+	})
+	u.Query.Limit = 150
+
+	err := c.Bind(u)
+	if assert.Error(err) {
+		validationErrors := validator.ValidationErrors{}
+		if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 2) {
+			assert.Equal("sort_by", validationErrors[0].Field())
+			assert.Equal("Query.sort_by", validationErrors[0].Namespace())
+			assert.Equal("Limit", validationErrors[1].Field())
+		}
+	}
+}
+
+type translatedValidationTester struct {
+	Query struct {
+		SortBy string `binder:"sort_by" validate:"required"`
+		Limit  int    `binder:"limit" validate:"max=100"`
+	}
+}
+
+func TestValidatorTranslator(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	english := en.New()
+	trans, _ := ut.New(english, english).GetTranslator("en")
+	assert.NoError(binder.SetTranslator(trans, en_translations.RegisterDefaultTranslations))
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?limit=150", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := c.Bind(&translatedValidationTester{})
+	translated := &TranslatedValidationErrors{}
+	if assert.ErrorAs(err, &translated) {
+		assert.Equal(validator.ValidationErrorsTranslations{
+			"translatedValidationTester.Query.sort_by": "sort_by is a required field",
+			"translatedValidationTester.Query.limit":   "limit must be 100 or less",
+		}, translated.Translations)
+		assert.Contains(err.Error(), "sort_by is a required field, limit must be 100 or less")
+
+		// The errors of the validator are still available
+		validationErrors := validator.ValidationErrors{}
+		assert.ErrorAs(err, &validationErrors)
+	}
+
+	// Without a translator the errors are the ones of the validator
+	assert.NoError(binder.SetTranslator(nil))
+	err = c.Bind(&translatedValidationTester{})
+	assert.False(errors.As(err, &translated))
+}
+
+type validatorOptionTester struct {
+	Query struct {
+		Slug  string `binder:"slug" validate:"slug"`
+		Limit int    `binder:"limit" validate:"max=100"`
+	}
+}
+
+type validatorTagNameTester struct {
+	Query struct {
+		Limit int `param:"limit" validate:"max=100"`
+	}
+}
+
+func TestValidatorOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	validate := validator.New()
+	assert.NoError(validate.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return !strings.ContainsAny(fl.Field().String(), " /")
+	}))
+
+	e := echo.New()
+	e.Binder = New(WithValidator(validate))
+
+	req := httptest.NewRequest(http.MethodGet, "/users?slug=a+b&limit=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// The custom validation of the injected validator is used
+	err := c.Bind(&validatorOptionTester{})
+	validationErrors := validator.ValidationErrors{}
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("slug", validationErrors[0].Tag())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?slug=a-b&limit=10", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.NoError(c.Bind(&validatorOptionTester{}))
+
+	// Changing the tag name doesn't register a field-name function on the injected validator
+	injected := New(WithValidator(validate))
+	injected.SetTagName("param")
+	e.Binder = injected
+
+	req = httptest.NewRequest(http.MethodGet, "/users?slug=a-b&limit=150", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	err = c.Bind(&validatorTagNameTester{})
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("Limit", validationErrors[0].Field())
+	}
+
+	// Without validation the values are bound, but never validated
+	e.Binder = New(WithoutValidation())
+	assert.Error(e.Binder.(*Binder).RegisterValidation("slug", func(fl validator.FieldLevel) bool { return true }))
+
+	req = httptest.NewRequest(http.MethodGet, "/users?slug=a+b&limit=150", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data := validatorOptionTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("a b", data.Query.Slug)
+		assert.Equal(150, data.Query.Limit)
+	}
+}
+
+type sectionValidationTester struct {
+	Query struct {
+		Limit int `binder:"limit" validate:"max=100"`
+	}
+
+	Body struct {
+		Name string `json:"name" validate:"required"`
+	}
+}
+
+type embeddedSectionValidationTester struct {
+	sectionValidationTester
+}
+
+func TestSectionValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.SetSectionValidation(queryField, false)
+	e.Binder = binder
+
+	newContext := func(body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/users?limit=150", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	// The query isn't validated, while the body still is
+	data := sectionValidationTester{}
+	if assert.NoError(newContext(`{"name":"Aviv"}`).Bind(&data)) {
+		assert.Equal(150, data.Query.Limit)
+	}
+
+	err := newContext(`{}`).Bind(&sectionValidationTester{})
+	validationErrors := validator.ValidationErrors{}
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("Name", validationErrors[0].StructField())
+	}
+
+	// Sections of embedded structs are excluded by their path
+	embedded := embeddedSectionValidationTester{}
+	if assert.NoError(newContext(`{"name":"Aviv"}`).Bind(&embedded)) {
+		assert.Equal(150, embedded.Query.Limit)
+	}
+
+	// Turning the validation back on validates the query again
+	binder.SetSectionValidation(queryField, true)
+	err = newContext(`{"name":"Aviv"}`).Bind(&sectionValidationTester{})
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("Limit", validationErrors[0].StructField())
+	}
+}
+
+type errorCategoryTester struct {
+	Path struct {
+		Id string `binder:"id,required"`
+	}
+
+	Body struct {
+		Name string `json:"name" validate:"required"`
+	}
+}
+
+func TestErrorCategories(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	e.Binder = New()
+
+	newContext := func(method string, names, values []string) echo.Context {
+		req := httptest.NewRequest(method, "/users", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames(names...)
+		c.SetParamValues(values...)
+		return c
+	}
+
+	tests := map[error]echo.Context{
+		ErrMissingParam:      newContext(http.MethodPost, []string{"id"}, []string{""}),
+		ErrUnsupportedMethod: newContext(http.MethodGet, []string{"id"}, []string{"5"}),
+		ErrValidation:        newContext(http.MethodPost, []string{"id"}, []string{"5"}),
+	}
+
+	for category, c := range tests {
+		err := c.Bind(&errorCategoryTester{})
+
+		// The category is found through the HTTPError, and the message is the one of the underlying error
+		httpError := &echo.HTTPError{}
+		bindError := &BindError{}
+		if assert.ErrorAs(err, &httpError) && assert.ErrorAs(httpError.Internal, &bindError) {
+			assert.Equal(category, bindError.Category)
+			assert.Equal(bindError.Err.Error(), httpError.Message)
+		}
+
+		assert.ErrorIs(err, category)
+		for other := range tests {
+			if other != category {
+				assert.NotErrorIs(err, other)
+			}
+		}
+	}
+
+	// The validation errors are still found under the category
+	err := newContext(http.MethodPost, []string{"id"}, []string{"5"}).Bind(&errorCategoryTester{})
+	validationErrors := validator.ValidationErrors{}
+	assert.ErrorAs(err, &validationErrors)
+}
+
+func TestCategoryStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.SetStatusForValidation(http.StatusUnprocessableEntity)
+	binder.SetCategoryStatus(ErrUnsupportedMethod, http.StatusMethodNotAllowed)
+	e.Binder = binder
+
+	newContext := func(method, id string) echo.Context {
+		req := httptest.NewRequest(method, "/users", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c
+	}
+
+	tests := []struct {
+		c      echo.Context
+		status int
+	}{
+		{newContext(http.MethodPost, "5"), http.StatusUnprocessableEntity},
+		{newContext(http.MethodGet, "5"), http.StatusMethodNotAllowed},
+		{newContext(http.MethodPost, ""), http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		httpError := &echo.HTTPError{}
+		if assert.ErrorAs(test.c.Bind(&errorCategoryTester{}), &httpError) {
+			assert.Equal(test.status, httpError.Code)
+		}
+	}
+
+	// The sections that are bound on their own use the statuses as well
+	binder.SetCategoryStatus(ErrMissingParam, http.StatusNotFound)
+
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(binder.BindPathInto(newContext(http.MethodGet, "5"), &struct{}{}), &httpError) {
+		assert.Equal(http.StatusNotFound, httpError.Code)
+	}
+
+	// A zero status restores the default
+	binder.SetStatusForValidation(0)
+	if assert.ErrorAs(newContext(http.MethodPost, "5").Bind(&errorCategoryTester{}), &httpError) {
+		assert.Equal(http.StatusBadRequest, httpError.Code)
+	}
+}
+
+type charsetBodyTester struct {
+	Body struct {
+		Name string `json:"name"`
+	}
+}
+
+// Decodes Latin-1 bodies, whose bytes are the code points of the runes, so the tests don't depend on golang.org/x/text
+func decodeLatin1(charset string, r io.Reader) (io.Reader, error) {
+	if charset != "iso-8859-1" {
+		return nil, errors.New("unknown charset")
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	runes := make([]rune, len(raw))
+	for i, b := range raw {
+		runes[i] = rune(b)
+	}
+
+	return strings.NewReader(string(runes)), nil
+}
+
+func TestCharsetDecoder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	// `{"name":"José"}` in Latin-1, where the é is the single byte 0xE9
+	latin1 := []byte("{\"name\":\"Jos\xe9\"}")
+
+	newContext := func(contentType string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(latin1))
+		req.Header.Set("Content-Type", contentType)
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	// Without a charset decoder the body is decoded as UTF-8, so the invalid byte is replaced
+	data := charsetBodyTester{}
+	if assert.NoError(newContext("application/json; charset=iso-8859-1").Bind(&data)) {
+		assert.Equal("Jos\uFFFD", data.Body.Name)
+	}
+
+	binder.SetCharsetDecoder(decodeLatin1)
+
+	data = charsetBodyTester{}
+	if assert.NoError(newContext("application/json; charset=ISO-8859-1").Bind(&data)) {
+		assert.Equal("José", data.Body.Name)
+	}
+
+	// Charsets the decoder doesn't know are rejected
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(newContext("application/json; charset=klingon").Bind(&charsetBodyTester{}), &httpError) {
+		assert.Equal(http.StatusUnsupportedMediaType, httpError.Code)
+	}
+
+	// UTF-8 bodies are left as they are
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"José"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	data = charsetBodyTester{}
+	if assert.NoError(e.NewContext(req, httptest.NewRecorder()).Bind(&data)) {
+		assert.Equal("José", data.Body.Name)
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	assert.NoError(binder.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return !strings.ContainsAny(fl.Field().String(), " /")
+	}))
+	assert.Error(binder.RegisterValidation("", func(fl validator.FieldLevel) bool { return true }))
+
+	req := httptest.NewRequest(http.MethodGet, "/users?slug=a/b", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := c.Bind(&validatorOptionTester{})
+	validationErrors := validator.ValidationErrors{}
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("slug", validationErrors[0].Tag())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users?slug=a-b", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.NoError(c.Bind(&validatorOptionTester{}))
+}
+
+func TestValidationErrorFormatter(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	english := en.New()
+	trans, _ := ut.New(english, english).GetTranslator("en")
+	assert.NoError(binder.SetTranslator(trans, en_translations.RegisterDefaultTranslations))
+	binder.SetErrorFormatter(func(errs validator.ValidationErrors) interface{} {
+		messages := map[string]string{}
+		for _, err := range errs {
+			messages[err.Field()] = err.Translate(trans)
+		}
+
+		return messages
+	})
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?limit=150", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := c.Bind(&translatedValidationTester{})
+	httpError := &echo.HTTPError{}
+	if assert.ErrorAs(err, &httpError) {
+		assert.Equal(http.StatusBadRequest, httpError.Code)
+		assert.Equal(map[string]string{
+			"sort_by": "sort_by is a required field",
+			"limit":   "limit must be 100 or less",
+		}, httpError.Message)
+
+		// The validation errors are still the internal error
+		validationErrors := validator.ValidationErrors{}
+		assert.ErrorAs(httpError.Internal, &validationErrors)
+	}
+
+	// Without a formatter the message is the flat string
+	binder.SetErrorFormatter(nil)
+	err = c.Bind(&translatedValidationTester{})
+	if assert.ErrorAs(err, &httpError) {
+		assert.IsType("", httpError.Message)
+	}
 }
 
-type formTester struct {
-	Form struct {
-		Name string
-		Age  int       `binder:"custom"`
-		Data []float64 `binder:"data"`
+type sectionPrefixTester struct {
+	Query struct {
+		Name  string `binder:"name"`
+		Age   int    `binder:"age"`
+		Users []struct {
+			Name string `binder:"name"`
+		} `binder:"users"`
+	}
+
+	Header struct {
+		Version string `binder:"X-Version"`
 	}
 }
 
-type formEmbeddedTester struct {
-	Form struct {
-		string
+func TestSectionPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.SetSectionPrefix(queryField, "v2_")
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodGet, "/users?v2_name=Koren&v2_age=3&name=ignored&v2_users[0].name=Omri", nil)
+	req.Header.Set("X-Version", "v2")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Only the identifiers of the section are prefixed, both of the indexed keys and of the other sections aren't
+	data := sectionPrefixTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("Koren", data.Query.Name)
+		assert.Equal(3, data.Query.Age)
+		if assert.Len(data.Query.Users, 1) {
+			assert.Equal("Omri", data.Query.Users[0].Name)
+		}
+		assert.Equal("v2", data.Header.Version)
+	}
+
+	// A binder without the prefix binds the same struct from the unprefixed params
+	e.Binder = New()
+	data = sectionPrefixTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("ignored", data.Query.Name)
+		assert.Zero(data.Query.Age)
 	}
 }
 
-type formValidEmbeddedTester struct {
-	Form struct {
-		validEmbedded
+type jsonTagFallbackTester struct {
+	Query struct {
+		SortBy  string `json:"sort_by,omitempty"`
+		Page    int    `binder:"p" json:"page"`
+		Limit   int    `json:",omitempty"`
+		Private string `json:"-"`
+	}
+
+	Header struct {
+		RequestID string `json:"X-Request-Id"`
 	}
 }
 
-func TestFormBinder(t *testing.T) {
+func TestJSONTagFallback(t *testing.T) {
 	assert := assert.New(t)
 
 	e := echo.New()
 	binder := New()
-	binder.CallEchoDefaultBinderOnError(true)
+	binder.JSONTagFallback(true)
 	e.Binder = binder
 
-	// Validate normal form binding
-	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("Name=Koren&custom=15&data=3.14157&data=152.32&Data=0"))
+	req := httptest.NewRequest(http.MethodGet, "/users?sort_by=name&p=2&page=3&Limit=10&Private=x", nil)
+	req.Header.Set("X-Request-Id", "abc")
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	normal := new(formTester)
-	err := c.Bind(normal)
-	if assert.NoError(err) {
-		assert.Equal("Koren", normal.Form.Name)
-		assert.Equal(15, normal.Form.Age)
-		assert.Equal([]float64{3.14157, 152.32}, normal.Form.Data)
+	// The binder tag takes precedence over the json tag, which takes precedence over the field name
+	data := jsonTagFallbackTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal("name", data.Query.SortBy)
+		assert.Equal(2, data.Query.Page)
+		assert.Equal(10, data.Query.Limit)
+		assert.Equal("x", data.Query.Private)
+		assert.Equal("abc", data.Header.RequestID)
 	}
 
-	// Validate embedded form binding
-	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("Name=Koren&custom=15&data=3.14157&data=152.32&Data=0"))
-	rec = httptest.NewRecorder()
-	c = e.NewContext(req, rec)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Without the flag the json tags are ignored
+	e.Binder = New()
+	data = jsonTagFallbackTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Empty(data.Query.SortBy)
+		assert.Empty(data.Header.RequestID)
+	}
+}
 
-	embedded := new(formEmbeddedTester)
-	err = c.Bind(embedded)
-	assert.Error(err)
+func TestBindAndRespond(t *testing.T) {
+	assert := assert.New(t)
 
-	// Validate valid embedded form binding
-	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("Name=Koren&custom=15&data=3.14157&data=152.32&Data=0&key1=value1&key2=2"))
-	rec = httptest.NewRecorder()
-	c = e.NewContext(req, rec)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
 
-	validEmbedded := new(formValidEmbeddedTester)
-	err = c.Bind(validEmbedded)
-	if assert.NoError(err) {
-		assert.Equal("value1", validEmbedded.Form.Key1)
-		assert.Equal(2, validEmbedded.Form.Key2)
+	newContext := func(target string) (echo.Context, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		return e.NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec), rec
+	}
+
+	// Successful bindings don't write anything
+	c, rec := newContext("/users?sort_by=name&limit=10")
+	data := translatedValidationTester{}
+	if assert.True(binder.BindAndRespond(&data, c)) {
+		assert.Equal("name", data.Query.SortBy)
+		assert.Empty(rec.Body.String())
+	}
+
+	// Validation errors are reported by the paths of their fields
+	c, rec = newContext("/users?limit=150")
+	assert.False(binder.BindAndRespond(&translatedValidationTester{}, c))
+	assert.Equal(http.StatusBadRequest, rec.Code)
+
+	response := ErrorResponse{}
+	if assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response)) {
+		assert.Equal("validation failed", response.Message)
+		if assert.Len(response.Errors, 2) {
+			assert.Contains(response.Errors["Query.sort_by"], "'required' tag")
+			assert.Contains(response.Errors["Query.limit"], "'max' tag")
+		}
 	}
+
+	// The messages of the fields are translated with the translator
+	english := en.New()
+	trans, _ := ut.New(english, english).GetTranslator("en")
+	assert.NoError(binder.SetTranslator(trans, en_translations.RegisterDefaultTranslations))
+
+	c, rec = newContext("/users?limit=150")
+	assert.False(binder.BindAndRespond(&translatedValidationTester{}, c))
+	assert.JSONEq(`{
+		"message": "validation failed",
+		"errors": {"Query.sort_by": "sort_by is a required field", "Query.limit": "limit must be 100 or less"}
+	}`, rec.Body.String())
+
+	// Other errors only have their message
+	c, rec = newContext("/users?sort_by=name&limit=many")
+	assert.False(binder.BindAndRespond(&translatedValidationTester{}, c))
+	assert.Equal(http.StatusBadRequest, rec.Code)
+
+	response = ErrorResponse{}
+	if assert.NoError(json.Unmarshal(rec.Body.Bytes(), &response)) {
+		assert.Contains(response.Message, `"many"`)
+		assert.Nil(response.Errors)
+	}
+
+	// The value of the error formatter is written as is
+	binder.SetErrorFormatter(func(errs validator.ValidationErrors) interface{} {
+		return map[string]int{"count": len(errs)}
+	})
+
+	c, rec = newContext("/users?limit=150")
+	assert.False(binder.BindAndRespond(&translatedValidationTester{}, c))
+	assert.JSONEq(`{"count": 2}`, rec.Body.String())
 }
 
-type validateTester struct {
+type customTagTester struct {
+	Query struct {
+		Page   int    `param:"page"`
+		SortBy string `param:"sort_by" validate:"required"`
+		Limit  int
+		Legacy string `binder:"legacy"`
+		Hidden string `param:"-"`
+	}
+
 	Header struct {
-		Name    string `validate:"required"`
-		Version string
+		Version string `param:"X-Version"`
 	}
 }
 
-func TestValidator(t *testing.T) {
+func TestCustomTagNameBinder(t *testing.T) {
 	assert := assert.New(t)
 
 	e := echo.New()
 	binder := New()
-	binder.CallEchoDefaultBinderOnError(true)
+	binder.SetTagName("param")
 	e.Binder = binder
 
-	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req := httptest.NewRequest(http.MethodGet, "/users?page=2&sort_by=name&Limit=10&legacy=x&Legacy=y&Hidden=z", nil)
+	req.Header.Set("X-Version", "v2")
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-	c.Request().Header.Set("Name", "Omri")
-	c.Request().Header.Set("Version", "132")
 
-	normal := new(validateTester)
-	err := c.Bind(normal)
-	if assert.NoError(err) {
-		assert.Equal("Omri", normal.Header.Name)
-		assert.Equal("132", normal.Header.Version)
+	data := customTagTester{}
+	if assert.NoError(c.Bind(&data)) {
+		assert.Equal(2, data.Query.Page)
+		assert.Equal("name", data.Query.SortBy)
+		assert.Equal(10, data.Query.Limit)
+		// The binder tag isn't read anymore, so the field is bound by its name
+		assert.Equal("y", data.Query.Legacy)
+		assert.Empty(data.Query.Hidden)
+		assert.Equal("v2", data.Header.Version)
 	}
 
-	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	// The validation errors report the fields by the custom tag as well
+	req = httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
 	rec = httptest.NewRecorder()
 	c = e.NewContext(req, rec)
-	c.Request().Header.Set("Version", "132")
 
-	normal = new(validateTester)
-	err = c.Bind(normal)
-	assert.Error(err)
+	err := c.Bind(&customTagTester{})
+	validationErrors := validator.ValidationErrors{}
+	if assert.ErrorAs(err, &validationErrors) && assert.Len(validationErrors, 1) {
+		assert.Equal("sort_by", validationErrors[0].Field())
+	}
+
+	// The schemas of the default tag are cached separately
+	req = httptest.NewRequest(http.MethodGet, "/users?legacy=x&page=3&SortBy=name", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+
+	data = customTagTester{}
+	if assert.NoError(New().Bind(&data, c)) {
+		assert.Equal("x", data.Query.Legacy)
+		assert.Equal("name", data.Query.SortBy)
+		assert.Zero(data.Query.Page)
+	}
 }
 
 type bodySentEmbedded struct {
@@ -714,6 +5813,222 @@ func TestBodySentFieldsBinder(t *testing.T) {
 		assert.False(u.BodySentFields.FieldExists("nested.field2"))
 		assert.True(u.BodySentFields.FieldExists("nested.nested.field"))
 		assert.True(u.BodySentFields.FieldExists("example"))
+
+		assert.Equal([]string{"age", "example", "name", "nested.field", "nested.nested.field"}, u.BodySentFields.Keys())
+	}
+}
+
+func TestLookupTableFieldExists(t *testing.T) {
+	assert := assert.New(t)
+
+	table := lookupTable{
+		"name": "Omri",
+		"nested": lookupTable{
+			"field": true,
+			"nested": lookupTable{
+				"field": false,
+			},
+		},
+	}
+
+	assert.True(table.FieldExists("name"))
+	assert.True(table.FieldExists("nested.field"))
+	assert.True(table.FieldExists("nested.nested"))
+	assert.True(table.FieldExists("nested.nested.field"))
+
+	// The nested keys are looked up in the nested tables, and not in the table itself
+	assert.False(table.FieldExists("nested.name"))
+	assert.False(table.FieldExists("nested.nested.nested"))
+	assert.False(table.FieldExists("nested.nested.field.field"))
+	assert.False(table.FieldExists("other.field"))
+}
+
+func TestRecursiveLookupTableKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	table := RecursiveLookupTable{
+		"b":     {},
+		"a":     {"z": {}, "y": {"x": {}}},
+		"empty": {},
+	}
+	assert.Equal([]string{"a.y.x", "a.z", "b", "empty"}, table.Keys())
+
+	// An empty table has no keys, but still returns a non-nil slice
+	empty := RecursiveLookupTable{}
+	assert.Equal([]string{}, empty.Keys())
+}
+
+func TestDisableBodySentFields(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	binder.DisableBodySentFields(true)
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"Omri","age":15}`))
+	req.Header.Set("Content-Type", "application/json")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	// The body is still bound, but none of its fields are tracked
+	u := new(bodySentFieldsTester)
+	if assert.NoError(c.Bind(u)) {
+		assert.Equal("Omri", u.Body.Name)
+		assert.Nil(u.BodySentFields)
+		assert.False(u.BodySentFields.FieldExists("name"))
+	}
+
+	form := url.Values{"name": {"Omri"}}
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	data := new(struct {
+		Form struct {
+			Name string `binder:"name"`
+		}
+
+		BodySentFields RecursiveLookupTable
+	})
+	if assert.NoError(c.Bind(data)) {
+		assert.Equal("Omri", data.Form.Name)
+		assert.False(data.BodySentFields.FieldExists("name"))
+	}
+
+	// Turning it back on tracks the fields again
+	binder.DisableBodySentFields(false)
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"Omri"}`))
+	req.Header.Set("Content-Type", "application/json")
+	c = e.NewContext(req, httptest.NewRecorder())
+
+	u = new(bodySentFieldsTester)
+	if assert.NoError(c.Bind(u)) {
+		assert.True(u.BodySentFields.FieldExists("name"))
+	}
+}
+
+type bodyYAMLTester struct {
+	Body struct {
+		Name   string `yaml:"name"`
+		Age    int    `yaml:"age"`
+		Nested struct {
+			Field bool     `yaml:"field"`
+			Tags  []string `yaml:"tags"`
+		} `yaml:"nested"`
+	}
+
+	BodySentFields RecursiveLookupTable
+}
+
+func TestBodyYAMLBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	data := "name: Omri\nnested:\n  field: true\n  tags: [a, b]\n"
+
+	for _, contentType := range []string{"application/yaml", "application/x-yaml", "text/yaml; charset=utf-8"} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data))
+		rec := httptest.NewRecorder()
+		req.Header.Set("Content-Type", contentType)
+		c := e.NewContext(req, rec)
+
+		u := new(bodyYAMLTester)
+		if assert.NoError(c.Bind(u), contentType) {
+			assert.Equal("Omri", u.Body.Name)
+			assert.Zero(u.Body.Age)
+			assert.True(u.Body.Nested.Field)
+			assert.Equal([]string{"a", "b"}, u.Body.Nested.Tags)
+
+			assert.True(u.BodySentFields.FieldExists("name"))
+			assert.False(u.BodySentFields.FieldExists("age"))
+			assert.True(u.BodySentFields.FieldExists("nested.field"))
+			assert.True(u.BodySentFields.FieldExists("nested.tags"))
+			assert.False(u.BodySentFields.FieldExists("nested.other"))
+		}
+	}
+
+	// Malformed YAML should fail the binding
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name: [Omri"))
+	rec := httptest.NewRecorder()
+	req.Header.Set("Content-Type", "application/yaml")
+	c := e.NewContext(req, rec)
+	assert.Error(c.Bind(new(bodyYAMLTester)))
+}
+
+type bodySentFieldsXMLTester struct {
+	Body struct {
+		ID     string `xml:"id,attr"`
+		Name   string `xml:"name"`
+		Age    int    `xml:"age"`
+		Nested struct {
+			Field bool     `xml:"field"`
+			Tags  []string `xml:"tag"`
+		} `xml:"nested"`
+	}
+
+	BodySentFields RecursiveLookupTable
+}
+
+func TestBodySentFieldsXMLBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	data := `<user xmlns="urn:users" id="7"><name>Omri</name><nested><field>true</field><tag>a</tag><tag>b</tag></nested></user>`
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(data))
+	rec := httptest.NewRecorder()
+	req.Header.Set("Content-Type", "application/xml")
+	c := e.NewContext(req, rec)
+
+	u := new(bodySentFieldsXMLTester)
+	if assert.NoError(c.Bind(u)) {
+		assert.Equal("7", u.Body.ID)
+		assert.Equal("Omri", u.Body.Name)
+		assert.Equal([]string{"a", "b"}, u.Body.Nested.Tags)
+
+		assert.True(u.BodySentFields.FieldExists("id"))
+		assert.True(u.BodySentFields.FieldExists("name"))
+		assert.False(u.BodySentFields.FieldExists("age"))
+		assert.False(u.BodySentFields.FieldExists("xmlns"))
+		assert.True(u.BodySentFields.FieldExists("nested.field"))
+		assert.True(u.BodySentFields.FieldExists("nested.tag"))
+		assert.False(u.BodySentFields.FieldExists("nested.other"))
+	}
+}
+
+type bodySentFieldsFormTester struct {
+	Form struct {
+		Name string `binder:"name"`
+		Age  int    `binder:"age"`
+	}
+
+	BodySentFields RecursiveLookupTable
+}
+
+func TestBodySentFieldsFormBinder(t *testing.T) {
+	assert := assert.New(t)
+
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Omri&nickname=Omo"))
+	rec := httptest.NewRecorder()
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c := e.NewContext(req, rec)
+
+	u := new(bodySentFieldsFormTester)
+	if assert.NoError(c.Bind(u)) {
+		assert.Equal("Omri", u.Form.Name)
+		assert.True(u.BodySentFields.FieldExists("name"))
+		assert.True(u.BodySentFields.FieldExists("nickname"))
+		assert.False(u.BodySentFields.FieldExists("age"))
 	}
 }
 
@@ -750,3 +6065,154 @@ func TestDefaultBindBehavior(t *testing.T) {
 		assert.Equal("foo", u.Z.B)
 	}
 }
+
+type fuzzRecursive struct {
+	Name string `binder:"name"`
+	Next *fuzzRecursive
+}
+
+type fuzzUnexportedPointer struct {
+	Name  string `binder:"name"`
+	inner *validEmbedded
+}
+
+type fuzzUnusualKinds struct {
+	Chan      chan int           `binder:"chan"`
+	Func      func()             `binder:"func"`
+	Complex   complex128         `binder:"complex"`
+	Map       map[string]string  `binder:"map"`
+	Interface interface{}        `binder:"interface"`
+	Slice     []chan int         `binder:"slice"`
+	Array     [2]int             `binder:"array"`
+	PtrPtr    **int              `binder:"ptrptr"`
+	Nested    *fuzzUnusualNested `binder:"nested"`
+}
+
+type fuzzUnusualNested struct {
+	Value *complex64 `binder:"value"`
+}
+
+// Each target is a structure that the binder should refuse or bind gracefully, but never panic on
+var fuzzTargets = []func() interface{}{
+	func() interface{} { return new(struct{ Body chan int }) },
+	func() interface{} { return new(struct{ Body func() }) },
+	func() interface{} { return new(struct{ Query func() }) },
+	func() interface{} { return new(struct{ Header chan string }) },
+	func() interface{} { return new(struct{ Path *struct{ Name string } }) },
+	func() interface{} { return new(struct{ Query *fuzzRecursive }) },
+	func() interface{} {
+		return new(struct {
+			Query struct{ fuzzUnexportedPointer }
+		})
+	},
+	func() interface{} { return new(struct{ Query fuzzUnusualKinds }) },
+	func() interface{} { return new(struct{ Form fuzzUnusualKinds }) },
+	func() interface{} { return new(struct{ Header fuzzUnusualKinds }) },
+	func() interface{} { return new(struct{ Path fuzzUnusualKinds }) },
+	func() interface{} {
+		return new(struct {
+			Body           struct{ Name string }
+			BodySentFields map[string]int
+		})
+	},
+	func() interface{} { return new(struct{ Body *struct{ Name string } }) },
+	func() interface{} { return new(allTypes) },
+	func() interface{} { return (*pathNormalTester)(nil) },
+	func() interface{} { return nil },
+}
+
+func FuzzBinder(f *testing.F) {
+	f.Add(uint8(0), "GET", "name=a&chan=1", "x", "application/json", `{"Name":"a"}`)
+	f.Add(uint8(5), "GET", "name=a&Next=b", "1", "", "")
+	f.Add(uint8(7), "GET", "chan=1&func=2&complex=3&map=4&interface=5&slice=6&array=7&ptrptr=8&value=9", "", "", "")
+	f.Add(uint8(8), "POST", "", "", "application/x-www-form-urlencoded", "chan=1&ptrptr=2&value=3&array=4")
+	f.Add(uint8(11), "POST", "", "", "application/json", `{"Name":"a"}`)
+	f.Add(uint8(12), "PUT", "", "", "application/xml", `<a><Name>b</Name></a>`)
+
+	f.Fuzz(func(t *testing.T, target uint8, method, query, param, contentType, body string) {
+		switch method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead:
+		default:
+			method = http.MethodGet
+		}
+
+		e := echo.New()
+		binder := New()
+		e.Binder = binder
+
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.URL.RawQuery = query
+		req.Header.Set(echo.HeaderContentType, contentType)
+		for _, name := range []string{"chan", "func", "complex", "map", "interface", "slice", "array", "ptrptr", "value", "name"} {
+			req.Header.Set(name, param)
+		}
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("name", "value", "ptrptr")
+		c.SetParamValues(param, param, param)
+
+		// Only panics are interesting here, binding errors are expected
+		_ = c.Bind(fuzzTargets[int(target)%len(fuzzTargets)]())
+	})
+}
+
+type benchmarkTester struct {
+	Path struct {
+		UserId int    `binder:"id"`
+		Name   string `binder:"name"`
+	}
+
+	Query struct {
+		Page    int      `binder:"page"`
+		Limit   int      `binder:"limit" default:"20"`
+		Tags    []string `binder:"tags"`
+		Address *queryAddress
+	}
+
+	Header struct {
+		AcceptLanguage string `binder:"Accept-Language"`
+		UserAgent      string `binder:"User-Agent"`
+		RequestId      string `binder:"X-Request-Id"`
+	}
+
+	Body struct {
+		Title   string `json:"title" validate:"required"`
+		Content string `json:"content"`
+	}
+}
+
+// Binds the path, query, header and body sections of a request, the field layout of each section is cached
+// so this mostly measures the per request work. Caching the layouts cut it from 75 to 29 allocs/op, and the
+// features that were added since bring it to 45 allocs/op.
+func BenchmarkBind(b *testing.B) {
+	e := echo.New()
+	binder := New()
+	e.Binder = binder
+
+	body := `{"title":"Hello","content":"World"}`
+	u := new(benchmarkTester)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Only the binding itself is measured
+		b.StopTimer()
+		req := httptest.NewRequest(http.MethodDelete, "/users/3/Omri?page=2&tags=a&tags=b&city=Haifa", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "en-US")
+		req.Header.Set("User-Agent", "benchmark")
+		req.Header.Set("X-Request-Id", "1234")
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("id", "name")
+		c.SetParamValues("3", "Omri")
+
+		*u = benchmarkTester{}
+		b.StartTimer()
+
+		if err := c.Bind(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
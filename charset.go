@@ -0,0 +1,50 @@
+package echo_binder
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"strings"
+)
+
+// Returns the charset parameter of the content type in lower case, or an empty string if it has none
+func getContentCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(params["charset"])
+}
+
+// Returns whether the body of the content type has to be transcoded to UTF-8 before it's decoded
+func (binder *Binder) needsTranscoding(contentType string) bool {
+	if binder.charsetDecoder == nil {
+		return false
+	}
+
+	switch getContentCharset(contentType) {
+	case "", "utf-8", "utf8":
+		return false
+	}
+
+	return true
+}
+
+// Transcodes the body from the charset of the content type (such as `iso-8859-1`) to UTF-8 with the charset decoder,
+// the charsets the decoder doesn't know fail with 415 Unsupported Media Type
+func (binder *Binder) transcodeBody(contentType string, body []byte) ([]byte, error) {
+	charset := getContentCharset(contentType)
+
+	reader, err := binder.charsetDecoder(charset, bytes.NewReader(body))
+	if err != nil || reader == nil {
+		return nil, unsupportedMediaTypeError(getUnsupportedCharsetAtLocationError(bodyField, charset))
+	}
+
+	transcoded, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, badRequestError(err)
+	}
+
+	return transcoded, nil
+}
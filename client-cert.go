@@ -0,0 +1,30 @@
+package echo_binder
+
+import (
+	"crypto/x509"
+)
+
+// Returns the values of the certificate attributes that can be bound by the `ClientCert` section, by their identifiers
+func getClientCertValues(cert *x509.Certificate) map[string][]string {
+	values := map[string][]string{
+		"CommonName":         {cert.Subject.CommonName},
+		"SerialNumber":       {cert.SerialNumber.String()},
+		"IssuerCommonName":   {cert.Issuer.CommonName},
+		"DNSNames":           cert.DNSNames,
+		"EmailAddresses":     cert.EmailAddresses,
+		"Organization":       cert.Subject.Organization,
+		"OrganizationalUnit": cert.Subject.OrganizationalUnit,
+		"IPAddresses":        make([]string, 0, len(cert.IPAddresses)),
+		"URIs":               make([]string, 0, len(cert.URIs)),
+	}
+
+	for _, address := range cert.IPAddresses {
+		values["IPAddresses"] = append(values["IPAddresses"], address.String())
+	}
+
+	for _, uri := range cert.URIs {
+		values["URIs"] = append(values["URIs"], uri.String())
+	}
+
+	return values
+}
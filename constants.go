@@ -1,15 +1,87 @@
 package echo_binder
 
 const (
-	pathField      string = "Path"
-	queryField     string = "Query"
-	bodyField      string = "Body"
-	formField      string = "Form"
-	headerField    string = "Header"
-	bodySentFields string = "BodySentFields"
+	pathField       string = "Path"
+	queryField      string = "Query"
+	bodyField       string = "Body"
+	formField       string = "Form"
+	headerField     string = "Header"
+	fileField       string = "File"
+	clientCertField string = "ClientCert"
+	cookieField     string = "Cookie"
+	requestField    string = "Request"
+	bodySentFields  string = "BodySentFields"
+
+	// RFC 7386, the patch is merged onto the current value of the body
+	mimeApplicationMergePatchJSON string = "application/merge-patch+json"
+
+	mimeApplicationYAML  string = "application/yaml"
+	mimeApplicationXYAML string = "application/x-yaml"
+	mimeTextYAML         string = "text/yaml"
 
 	TagIdentifier string = "binder"
+	timeFormatTag string = "time_format"
+	defaultTag    string = "default"
+	jsonTag       string = "json"
+	explodeTag    string = "explode"
+	encodingTag   string = "encoding"
+
+	jsonOption      string = "json"
+	jsonArrayOption string = "jsonarray"
+	xmlOption       string = "xml"
+	qvaluesOption   string = "qvalues"
+	etagsOption     string = "etags"
+	byteRangeOption string = "byterange"
+	generateOption  string = "generate"
+	indexedOption   string = "indexed"
+	hmacOption      string = "hmac"
+	decryptOption   string = "decrypt"
+	presenceOption  string = "presence"
+	lowerOption     string = "lower"
+	upperOption     string = "upper"
+	intboolOption   string = "intbool"
+	restOption      string = "rest"
+	rawOption       string = "raw"
+	maxOption       string = "max"
+	truncateOption  string = "truncate"
+	basicOption     string = "basic"
+	groupOption     string = "group"
+	requiredOption  string = "required"
+	absentOption    string = "absentfalse"
+	styleOption     string = "style"
+	explodeOption   string = "explode"
+
+	nestedQueryOption    string = "nested-query"
+	underscoresOption    string = "underscores"
+	unquoteOption        string = "unquote"
+	rangeSeparatorOption string = "rangesep"
+
+	uuidGenerator string = "uuid"
+
+	// The encodings of the []byte fields in the `encoding` tag, base64 is the URL-safe one
+	base64Encoding string = "base64"
+	hexEncoding    string = "hex"
+
+	// The OpenAPI serialization styles of the `style` option
+	formStyle           string = "form"
+	spaceDelimitedStyle string = "spaceDelimited"
+	pipeDelimitedStyle  string = "pipeDelimited"
+	deepObjectStyle     string = "deepObject"
+
+	// The maximum length of a pattern that is bound into a regexp.Regexp field
+	maxRegexpLength int = 1000
+
+	// The maximum length of a slice that is bound from indexed keys (`users[0].name`), to avoid huge allocations
+	maxIndexedSliceLength int = 1000
 
 	structTypeString string = "struct"
+	sliceTypeString  string = "slice"
+	boolTypeString   string = "bool"
 	lookupTypeString string = "echo_binder.RecursiveLookupTable"
+
+	fileHeaderTypeString string = "*multipart.FileHeader"
+	mapTypeString        string = "map[string]string"
+	basicAuthTypeString  string = "struct { Username string; Password string }"
+	rangeTypeString      string = "struct { Start time.Time; End time.Time }"
+	byteRangesTypeString string = "[]echo_binder.ByteRange"
 )
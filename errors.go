@@ -1,42 +1,284 @@
 package echo_binder
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
 var (
-	errorInvalidType           = errors.New("binding element must be a pointer to a struct")
-	errorInvalidAnonymousField = errors.New("binding element cannot have embedded fields that arent struct")
+	errorInvalidType            = errors.New("binding element must be a pointer to a struct")
+	errorMissingSignatureSecret = errors.New("signature secret must be set to verify signatures")
+	errorMissingHeaderDecryptor = errors.New("header decryptor must be set to decrypt headers")
+	errorTrailingBodyData       = errors.New("body must contain a single JSON value")
+	errorValidationDisabled     = errors.New("validation is disabled, the binder was created with WithoutValidation")
 )
 
+// The categories of the binding errors, the errors of a category are *BindError so they can be told apart with
+// errors.Is (such as `errors.Is(err, echo_binder.ErrValidation)`) or errors.As on the internal error of the HTTPError
+var (
+	ErrMissingParam      = errors.New("missing param")
+	ErrUnsupportedMethod = errors.New("unsupported http method")
+	ErrValidation        = errors.New("validation failed")
+)
+
+// An error of a known category (one of the Err variables), it keeps the message of the error it wraps
+type BindError struct {
+	Category error
+	Err      error
+}
+
+func (err *BindError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *BindError) Unwrap() error {
+	return err.Err
+}
+
+// Reports whether target is the category of the error
+func (err *BindError) Is(target error) bool {
+	return target == err.Category
+}
+
 func getInvalidTypeAtLocationError(location, requiredType string) error {
 	return fmt.Errorf("binding element at `%s` must be a `%s`", location, requiredType)
 }
 
 func getMissingParamAtLocationError(location, param string) error {
-	return fmt.Errorf("missing param `%s` at `%s`", param, location)
+	return &BindError{Category: ErrMissingParam, Err: fmt.Errorf("missing param `%s` at `%s`", param, location)}
+}
+
+func getRequiredParamAtLocationError(location, param string) error {
+	return &BindError{Category: ErrMissingParam, Err: fmt.Errorf("required param `%s` is missing at `%s`", param, location)}
 }
 
 func getNotSettableParamAtLocationError(location, param string) error {
 	return fmt.Errorf("param `%s` at `%s` is not settable", param, location)
 }
 
+func getUnsupportedKindAtLocationError(location, field string, kind reflect.Kind) error {
+	return fmt.Errorf("field `%s` of kind %s cannot be bound at `%s`", field, kind, location)
+}
+
+func getInvalidElementAtLocationError(location, param string, index int, value string, elemType reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("%s `%s[%d]` is out of range for %s, got `%s`", strings.ToLower(location), param, index, elemType, value)
+	}
+
+	return fmt.Errorf("%s `%s[%d]` must be %s, got `%s`", strings.ToLower(location), param, index, describeExpectedValue(elemType), value)
+}
+
+func getInvalidBoolAtLocationError(location, param, value string) error {
+	return fmt.Errorf("%s `%s` must be a boolean, got `%s`", strings.ToLower(location), param, value)
+}
+
+func getTooManyValuesAtLocationError(location, param string, max, count int) error {
+	return fmt.Errorf("%s `%s` accepts at most %d values, got %d", strings.ToLower(location), param, max, count)
+}
+
+func getNegativeValueAtLocationError(location, param, value string) error {
+	return fmt.Errorf("%s param `%s` must be a non-negative integer, got `%s`", strings.ToLower(location), param, value)
+}
+
+// Describes the values that can be bound into the type, for the errors of the invalid values
+func describeExpectedValue(valueType reflect.Type) string {
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType {
+	case durationType:
+		return "a duration"
+	case timeType:
+		return "a time"
+	}
+
+	switch valueType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	}
+
+	return "a valid " + valueType.String()
+}
+
+func getConversionAtLocationError(location, field string, err error) error {
+	return fmt.Errorf("cannot convert the value of field `%s` at `%s`: %w", field, location, err)
+}
+
+func getInvalidConverterResultError(valueType reflect.Type, value interface{}) error {
+	return fmt.Errorf("converter of `%s` returned a value of type `%T`", valueType, value)
+}
+
+func getTooManyParamsAtLocationError(location string, max int) error {
+	return fmt.Errorf("too many params at `%s`, at most %d are allowed", location, max)
+}
+
+func getTooManyFilesAtLocationError(location, param string, count int) error {
+	return fmt.Errorf("expected a single file for `%s` at `%s`, got %d", param, location, count)
+}
+
+func getUnknownParamAtLocationError(location, param string) error {
+	return fmt.Errorf("unknown param `%s` at `%s`", param, location)
+}
+
+func getUnknownFieldsAtLocationError(location string, fields []string) error {
+	return fmt.Errorf("unknown fields `%s` at `%s`", strings.Join(fields, "`, `"), location)
+}
+
+func getDuplicateIdentifierError(location, identifier string) error {
+	return fmt.Errorf("duplicate binder identifier `%s` in %s", identifier, location)
+}
+
+func getMalformedParamAtLocationError(location, param string, err error) error {
+	return fmt.Errorf("malformed param `%s` at `%s`: %w", param, location, err)
+}
+
+func getIncompleteRangeError(separator string) error {
+	return fmt.Errorf("a range must have both a start and an end separated by `%s`", separator)
+}
+
+func getInvalidRangeBoundError(bound string, err error) error {
+	return fmt.Errorf("invalid `%s` of the range: %w", bound, err)
+}
+
 func getUnsupportedHttpMethodError(location, method string) error {
-	return fmt.Errorf("unsupported http method `%s` at `%s`", method, location)
+	return &BindError{Category: ErrUnsupportedMethod, Err: fmt.Errorf("unsupported http method `%s` at `%s`", method, location)}
 }
 
 func getInvalidAnonymousFieldError(location string) error {
 	return fmt.Errorf("binding element at `%s` cannot have embedded fields that arent struct", location)
 }
 
+func getIndexOutOfRangeError(location, param string, max int) error {
+	return fmt.Errorf("param `%s` at `%s` exceeds the maximum index %d", param, location, max-1)
+}
+
+func getInvalidSignatureAtLocationError(location, param string) error {
+	return fmt.Errorf("invalid signature `%s` at `%s`", param, location)
+}
+
+func getInvalidOptionValueError(location, field, option, value string) error {
+	return fmt.Errorf("invalid value `%s` of the `%s` option of `%s` at `%s`", value, option, field, location)
+}
+
+func getDecryptionAtLocationError(location, param string, err error) error {
+	return fmt.Errorf("failed to decrypt param `%s` at `%s`: %w", param, location, err)
+}
+
+func getFileTooLargeAtLocationError(location, param string, max int64) error {
+	return fmt.Errorf("file `%s` at `%s` is larger than %d bytes", param, location, max)
+}
+
+func getUnsupportedCharsetAtLocationError(location, charset string) error {
+	return fmt.Errorf("unsupported charset `%s` at `%s`", charset, location)
+}
+
+func getUnknownGeneratorError(location, generator string) error {
+	return fmt.Errorf("unknown generator `%s` at `%s`", generator, location)
+}
+
 func badRequestError(err error) *echo.HTTPError {
+	// Errors that are already HTTP errors keep their status and message instead of being wrapped again
+	if httpError, ok := err.(*echo.HTTPError); ok {
+		return httpError
+	}
+
 	return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 }
 
+// Returns the error for a failure to read the request body, a canceled or timed out request is the client's fault
+func readBodyError(err error) *echo.HTTPError {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return echo.NewHTTPError(http.StatusRequestTimeout, err.Error()).SetInternal(err)
+	case errors.Is(err, context.Canceled):
+		return badRequestError(err)
+	}
+
+	return internalServerError(err)
+}
+
+func fileTooLargeError(err error) *echo.HTTPError {
+	return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error()).SetInternal(err)
+}
+
+func unsupportedMediaTypeError(err error) *echo.HTTPError {
+	return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error()).SetInternal(err)
+}
+
 func internalServerError(err error) *echo.HTTPError {
 	return echo.NewHTTPError(http.StatusInternalServerError, err.Error()).SetInternal(err)
 }
+
+// The body of the responses that BindAndRespond writes on errors, Errors holds the message of every field that failed
+// the validation by its path in the request (such as `Query.sort_by`)
+type ErrorResponse struct {
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// Returns the response of the binding error, the messages of the fields are translated by trans when it's set
+func getErrorResponse(httpError *echo.HTTPError, trans ut.Translator) ErrorResponse {
+	response := ErrorResponse{Message: http.StatusText(httpError.Code)}
+	if message, ok := httpError.Message.(string); ok {
+		response.Message = message
+	}
+
+	validationErrors := validator.ValidationErrors{}
+	if !errors.As(httpError, &validationErrors) {
+		return response
+	}
+
+	response.Message = ErrValidation.Error()
+	response.Errors = make(map[string]string, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		// The namespace starts with the name of the request struct, which the client doesn't know about
+		path := fieldError.Namespace()
+		if index := strings.Index(path, "."); index >= 0 {
+			path = path[index+1:]
+		}
+
+		if trans != nil {
+			response.Errors[path] = fieldError.Translate(trans)
+		} else {
+			response.Errors[path] = fieldError.Error()
+		}
+	}
+
+	return response
+}
+
+// The validation errors of a binder with a translator, Translations holds the translated message of every field by
+// its namespace (for example `Request.Query.sort_by`). It unwraps into the validator.ValidationErrors.
+type TranslatedValidationErrors struct {
+	Errors       validator.ValidationErrors
+	Translations validator.ValidationErrorsTranslations
+}
+
+func (err *TranslatedValidationErrors) Error() string {
+	messages := make([]string, len(err.Errors))
+	for i, fieldError := range err.Errors {
+		messages[i] = err.Translations[fieldError.Namespace()]
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+func (err *TranslatedValidationErrors) Unwrap() error {
+	return err.Errors
+}
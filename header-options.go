@@ -0,0 +1,343 @@
+package echo_binder
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type qualityValue struct {
+	value   string
+	quality float64
+}
+
+// Parses a header with weighted values (for example `Accept-Language: en;q=0.8, fr;q=0.9`) into its values,
+// ordered by descending quality. Values with the same quality keep their order, and values with `q=0` are dropped
+// since they are explicitly not acceptable.
+func parseQValues(header string) ([]string, error) {
+	items := []qualityValue{}
+
+	for _, item := range strings.Split(header, ",") {
+		params := strings.Split(item, ";")
+		value := strings.TrimSpace(params[0])
+		if value == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			key, raw, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				return nil, errors.New("invalid quality value `" + raw + "` for `" + value + "`")
+			}
+
+			quality = parsed
+		}
+
+		if quality > 0 {
+			items = append(items, qualityValue{value: value, quality: quality})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].quality > items[j].quality
+	})
+
+	values := make([]string, len(items))
+	for i, item := range items {
+		values[i] = item.value
+	}
+
+	return values, nil
+}
+
+// An entity tag of a conditional header (such as `If-None-Match: W/"a", "b"`), Value is the opaque tag without its
+// quotes and Weak is set for tags with the `W/` prefix. The `*` wildcard is a strong tag with the value `*`.
+type ETag struct {
+	Value string
+	Weak  bool
+}
+
+// Parses a header with a list of entity tags (RFC 7232) into its tags, in the order they were sent
+func parseETags(header string) ([]ETag, error) {
+	etags := []ETag{}
+
+	for _, item := range strings.Split(header, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if item == "*" {
+			etags = append(etags, ETag{Value: item})
+			continue
+		}
+
+		etag := ETag{}
+		if strings.HasPrefix(item, "W/") {
+			etag.Weak = true
+			item = item[len("W/"):]
+		}
+
+		if len(item) < 2 || item[0] != '"' || item[len(item)-1] != '"' || strings.Contains(item[1:len(item)-1], `"`) {
+			return nil, errors.New("invalid entity tag `" + item + "`")
+		}
+
+		etag.Value = item[1 : len(item)-1]
+		etags = append(etags, etag)
+	}
+
+	return etags, nil
+}
+
+// A range of the `Range` header (such as `Range: bytes=0-1023`), Start and End are the inclusive byte positions.
+// Open-ended ranges (`500-`) have an End of -1, and suffix ranges (`-500`, the last 500 bytes) have a Start of -1
+// and the length of the suffix as their End.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// Parses a `Range` header with the `bytes` unit (RFC 7233) into its ranges, in the order they were sent
+func parseByteRanges(header string) ([]ByteRange, error) {
+	unit, set, ok := strings.Cut(strings.TrimSpace(header), "=")
+	if !ok || !strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+		return nil, errors.New("range must be of the `bytes` unit")
+	}
+
+	ranges := []ByteRange{}
+	for _, item := range strings.Split(set, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		first, last, ok := strings.Cut(item, "-")
+		if !ok || (first == "" && last == "") {
+			return nil, errors.New("invalid byte range `" + item + "`")
+		}
+
+		start, startErr := parseBytePosition(first)
+		end, endErr := parseBytePosition(last)
+		if startErr != nil || endErr != nil {
+			return nil, errors.New("invalid byte range `" + item + "`")
+		}
+
+		byteRange := ByteRange{Start: start, End: end}
+		if byteRange.Start >= 0 && byteRange.End >= 0 && byteRange.End < byteRange.Start {
+			return nil, errors.New("invalid byte range `" + item + "`, its end is before its start")
+		}
+
+		ranges = append(ranges, byteRange)
+	}
+
+	if len(ranges) == 0 {
+		return nil, errors.New("range must contain at least one byte range")
+	}
+
+	return ranges, nil
+}
+
+// Parses a position of a byte range, the missing position of open-ended and suffix ranges is -1
+func parseBytePosition(position string) (int64, error) {
+	position = strings.TrimSpace(position)
+	if position == "" {
+		return -1, nil
+	}
+
+	value, err := strconv.ParseUint(position, 10, 63)
+	return int64(value), err
+}
+
+// Generates a random (version 4) UUID, this is the default `uuid` generator of the binder
+func generateUUID() (string, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return "", err
+	}
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
+}
+
+type indexedValue struct {
+	index  uint64
+	values []string
+}
+
+// Collects the values of all of the headers that are named by the prefix followed by a numeric index
+// (for example `X-Item-1`, `X-Item-2`), ordered by the index. Headers with a non numeric suffix are ignored.
+func getIndexedHeaderValues(header http.Header, prefix string) []string {
+	items := []indexedValue{}
+	prefix = strings.ToLower(prefix)
+
+	for key, values := range header {
+		if !strings.HasPrefix(strings.ToLower(key), prefix) {
+			continue
+		}
+
+		index, err := strconv.ParseUint(key[len(prefix):], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		items = append(items, indexedValue{index: index, values: values})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].index < items[j].index
+	})
+
+	values := []string{}
+	for _, item := range items {
+		values = append(values, item.values...)
+	}
+
+	return values
+}
+
+// Verifies in constant time that the signature is the hex encoded HMAC-SHA256 of the body, optionally prefixed by `sha256=`
+func verifyHMACSignature(secret []byte, body []byte, signature string) bool {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil || len(decoded) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
+
+// Decrypts the values of the header with the decryptor, empty values are not encrypted so they are kept as is
+func decryptHeaderValues(decryptor func(field, value string) (string, error), name string, values []string) ([]string, error) {
+	decrypted := make([]string, len(values))
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+
+		var err error
+		if decrypted[i], err = decryptor(name, value); err != nil {
+			return nil, getDecryptionAtLocationError(headerField, name, err)
+		}
+	}
+
+	return decrypted, nil
+}
+
+// Normalizes the case of the header values for fields tagged with the `lower` or `upper` option,
+// for enum headers that are sent in varying case (`X-Env: PRODUCTION` and `X-Env: production`)
+func transformHeaderValues(options tagOptions, values []string) []string {
+	var transform func(string) string
+
+	switch {
+	case options.Has(lowerOption):
+		transform = strings.ToLower
+	case options.Has(upperOption):
+		transform = strings.ToUpper
+	default:
+		return values
+	}
+
+	transformed := make([]string, len(values))
+	for i, value := range values {
+		transformed[i] = transform(value)
+	}
+
+	return transformed
+}
+
+// Returns the header name of an untagged header field, which is its name split into hyphenated words in the canonical
+// form of the header keys (`ContentType` is `Content-Type` and `XRequestID` is `X-Request-Id`)
+func getHeaderFieldName(fieldName string) string {
+	runes := []rune(fieldName)
+	words := []string{}
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		// A word starts at an upper case letter that follows a lower case one (or a digit), or that is followed by
+		// a lower case one after a run of upper case letters (the end of an acronym)
+		if unicode.IsUpper(runes[i]) && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	words = append(words, string(runes[start:]))
+	return textproto.CanonicalMIMEHeaderKey(strings.Join(words, "-"))
+}
+
+// Parses the credentials of a basic authorization header (`Basic base64(username:password)`)
+func parseBasicAuth(header string) (string, string, error) {
+	scheme, credentials, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Basic") {
+		return "", "", errors.New("authorization scheme must be `Basic`")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(credentials))
+	if err != nil {
+		return "", "", errors.New("credentials must be base64 encoded")
+	}
+
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", errors.New("credentials must be of the form `username:password`")
+	}
+
+	return username, password, nil
+}
+
+// Sets the credentials into the Username and Password fields of the struct (or pointer to a struct) of the field
+func setBasicAuthFields(field *structFieldData, username, password string) bool {
+	target := *field.Value
+	targetType := target.Type()
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	if targetType.Kind() != reflect.Struct {
+		return false
+	}
+
+	usernameField, ok := targetType.FieldByName("Username")
+	if !ok || usernameField.Type.Kind() != reflect.String {
+		return false
+	}
+
+	passwordField, ok := targetType.FieldByName("Password")
+	if !ok || passwordField.Type.Kind() != reflect.String {
+		return false
+	}
+
+	field.prepare()
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(targetType))
+		}
+
+		target = target.Elem()
+	}
+
+	target.FieldByIndex(usernameField.Index).SetString(username)
+	target.FieldByIndex(passwordField.Index).SetString(password)
+	return true
+}
@@ -1,7 +1,12 @@
 package echo_binder
 
 import (
+	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"sort"
 	"strings"
 )
 
@@ -22,7 +27,7 @@ func (l *lookupTable) FieldExists(key string) bool {
 
 	switch t := data.(type) {
 	case lookupTable:
-		return l.FieldExists(strings.Join(keys[1:], "."))
+		return t.FieldExists(strings.Join(keys[1:], "."))
 
 	default:
 		data, err := json.Marshal(&t)
@@ -39,6 +44,60 @@ func (l *lookupTable) FieldExists(key string) bool {
 	}
 }
 
+// Returns the lookup table of the keys of a form, which are all top level entries since forms are flat
+func getFormLookupTable(params url.Values) lookupTable {
+	data := lookupTable{}
+	for key := range params {
+		data[key] = lookupTable{}
+	}
+
+	return data
+}
+
+// Returns the lookup table of the elements of an XML document, the children (and attributes) of the root element
+// are the top level entries and their own children are nested in them
+func getXMLLookupTable(body []byte) (lookupTable, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	tables := []lookupTable{}
+	root := lookupTable{}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return root, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			table := root
+			if len(tables) > 0 {
+				// Repeated elements (of slices) are merged into a single entry
+				parent := tables[len(tables)-1]
+				if existing, ok := parent[t.Name.Local].(lookupTable); ok {
+					table = existing
+				} else {
+					table = lookupTable{}
+					parent[t.Name.Local] = table
+				}
+			}
+
+			for _, attr := range t.Attr {
+				// Namespace declarations are not fields
+				if attr.Name.Space != "xmlns" && attr.Name.Local != "xmlns" {
+					table[attr.Name.Local] = lookupTable{}
+				}
+			}
+
+			tables = append(tables, table)
+
+		case xml.EndElement:
+			tables = tables[:len(tables)-1]
+		}
+	}
+}
+
 func (l *lookupTable) IntoRecursiveLookupTable() RecursiveLookupTable {
 	rlt := RecursiveLookupTable{}
 
@@ -80,3 +139,24 @@ func (l *RecursiveLookupTable) FieldExists(key string) bool {
 
 	return data.FieldExists(strings.Join(keys[1:], "."))
 }
+
+// Returns the sorted dotted paths of all of the fields that were sent without nested fields of their own (such as
+// `name` and `nested.field`), the objects that hold other fields are only part of the paths of their fields
+func (l *RecursiveLookupTable) Keys() []string {
+	keys := []string{}
+	l.appendKeys("", &keys)
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (l *RecursiveLookupTable) appendKeys(prefix string, keys *[]string) {
+	for key, nested := range *l {
+		if len(nested) == 0 {
+			*keys = append(*keys, prefix+key)
+			continue
+		}
+
+		nested.appendKeys(prefix+key+".", keys)
+	}
+}
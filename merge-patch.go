@@ -0,0 +1,161 @@
+package echo_binder
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var jsonNull = []byte("null")
+
+// Merges the JSON merge patch (RFC 7386) onto the value: the members of an object patch are merged recursively
+// into structs, maps and pointers, null members reset fields to their zero value and delete the keys of maps,
+// while any other patch (arrays, scalars) replaces the current value as a whole
+func mergeJSONPatch(target reflect.Value, patch json.RawMessage) error {
+	if bytes.Equal(bytes.TrimSpace(patch), jsonNull) {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	members := map[string]json.RawMessage{}
+	if err := json.Unmarshal(patch, &members); err != nil || reflect.PtrTo(target.Type()).Implements(jsonUnmarshalerType) {
+		// The patch is not an object (or the type decodes itself), so it replaces the current value
+		return replaceJSONValue(target, patch)
+	}
+
+	switch target.Kind() {
+	case reflect.Ptr:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		return mergeJSONPatch(target.Elem(), patch)
+
+	case reflect.Struct:
+		for key, member := range members {
+			// Members without a field are skipped just like encoding/json does (the strict body rejects them before)
+			if field, ok := getJSONField(target, key); ok {
+				if err := mergeJSONPatch(field, member); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+
+	case reflect.Map:
+		if target.Type().Key().Kind() != reflect.String {
+			return replaceJSONValue(target, patch)
+		}
+
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+
+		for key, member := range members {
+			mapKey := reflect.ValueOf(key).Convert(target.Type().Key())
+			if bytes.Equal(bytes.TrimSpace(member), jsonNull) {
+				target.SetMapIndex(mapKey, reflect.Value{})
+				continue
+			}
+
+			// Map elements are not addressable, so the current element is merged in a copy that replaces it
+			elem := reflect.New(target.Type().Elem()).Elem()
+			if current := target.MapIndex(mapKey); current.IsValid() {
+				elem.Set(current)
+			}
+
+			if err := mergeJSONPatch(elem, member); err != nil {
+				return err
+			}
+
+			target.SetMapIndex(mapKey, elem)
+		}
+
+		return nil
+
+	case reflect.Interface:
+		if current, ok := target.Interface().(map[string]interface{}); ok {
+			elem := reflect.ValueOf(&current).Elem()
+			if err := mergeJSONPatch(elem, patch); err != nil {
+				return err
+			}
+
+			target.Set(elem)
+			return nil
+		}
+	}
+
+	return replaceJSONValue(target, patch)
+}
+
+// Decodes the value into a zero value of the target type, which replaces the current value of the target
+func replaceJSONValue(target reflect.Value, value json.RawMessage) error {
+	replacement := reflect.New(target.Type())
+	if err := json.Unmarshal(value, replacement.Interface()); err != nil {
+		return err
+	}
+
+	target.Set(replacement.Elem())
+	return nil
+}
+
+// Returns the struct field that encoding/json decodes the key into, by the same rules as getJSONFieldType, nil
+// embedded struct pointers on the way to the field are allocated
+func getJSONField(structValue reflect.Value, key string) (reflect.Value, bool) {
+	var folded reflect.Value
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _ := parseTag(field.Tag.Get(jsonTag))
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+
+			if embeddedType.Kind() == reflect.Struct {
+				if _, ok := getJSONFieldType(embeddedType, key); !ok {
+					continue
+				}
+
+				embedded := structValue.Field(i)
+				if embedded.Kind() == reflect.Ptr {
+					if embedded.IsNil() {
+						if !embedded.CanSet() {
+							continue
+						}
+
+						embedded.Set(reflect.New(embeddedType))
+					}
+
+					embedded = embedded.Elem()
+				}
+
+				return getJSONField(embedded, key)
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if name == key {
+			return structValue.Field(i), true
+		} else if !folded.IsValid() && strings.EqualFold(name, key) {
+			folded = structValue.Field(i)
+		}
+	}
+
+	return folded, folded.IsValid()
+}
@@ -0,0 +1,73 @@
+package echo_binder
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// The key the report of the binding is stored under in the echo.Context
+const reportContextKey = "echo_binder.report"
+
+// Describes what a single Bind call did, it's recorded when the binder was configured with binder.RecordReport(true)
+// and can be retrieved with ReportFromContext after the binding (even if it failed).
+type BindReport struct {
+	// The fields that were set, in the order they were bound
+	Fields []ReportField
+
+	// The params that were sent but weren't bound into any of the fields (and fail strict binding), by their section
+	SkippedParams map[string][]string
+
+	// How long the binding of every section took
+	SectionDurations map[string]time.Duration
+
+	// How long the whole binding took, including the validation
+	Duration time.Duration
+}
+
+// A field that was set by the binding
+type ReportField struct {
+	// The section the field was bound from, such as `Query` or `Header`
+	Section string
+
+	// The name of the param the field was bound from
+	Param string
+
+	// The name of the field in the section structure
+	FieldName string
+
+	// Whether the value was taken from the `default` tag since the param wasn't sent
+	Default bool
+}
+
+func newBindReport() *BindReport {
+	return &BindReport{
+		Fields:           []ReportField{},
+		SkippedParams:    map[string][]string{},
+		SectionDurations: map[string]time.Duration{},
+	}
+}
+
+// Returns the report of the last binding of the context, or nil if it wasn't recorded
+func ReportFromContext(c echo.Context) *BindReport {
+	report, _ := c.Get(reportContextKey).(*BindReport)
+	return report
+}
+
+// Records a field that was set, does nothing when the report isn't recorded
+func (report *BindReport) addField(section, param, fieldName string, isDefault bool) {
+	if report == nil {
+		return
+	}
+
+	report.Fields = append(report.Fields, ReportField{Section: section, Param: param, FieldName: fieldName, Default: isDefault})
+}
+
+// Records the params that weren't bound, does nothing when the report isn't recorded
+func (report *BindReport) addSkippedParams(section string, params []string) {
+	if report == nil || len(params) == 0 {
+		return
+	}
+
+	report.SkippedParams[section] = append(report.SkippedParams[section], params...)
+}
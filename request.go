@@ -0,0 +1,20 @@
+package echo_binder
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Returns the values of the request metadata that can be bound by the `Request` section, by their identifiers
+func getRequestValues(c echo.Context) map[string][]string {
+	values := map[string][]string{
+		"Method":  {c.Request().Method},
+		"URLPath": {c.Request().URL.Path},
+	}
+
+	// Requests that didn't match a route (or contexts that weren't routed) have no pattern
+	if pattern := c.Path(); pattern != "" {
+		values["RoutePattern"] = []string{pattern}
+	}
+
+	return values
+}
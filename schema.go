@@ -0,0 +1,321 @@
+package echo_binder
+
+import (
+	"net/textproto"
+	"reflect"
+	"sync"
+)
+
+// The layout of the bindable fields of a section structure. It only depends on the type of the structure,
+// so it's built once per type and cached, and every request just resolves the values of its fields.
+type structSchema struct {
+	entries []schemaEntry
+
+	// The section the structure is bound from
+	location string
+
+	// The number of fields that are bound as a single value, including the ones of the nested structures
+	size int
+
+	// The names of the fields that are skipped with the `-` tag, including the ones of the nested structures
+	skipped map[string]bool
+}
+
+type schemaEntry struct {
+	index int
+
+	// Set for fields that are bound as a single value
+	identifier string
+	name       string
+	tag        reflect.StructTag
+	options    tagOptions
+
+	// Set for nested structures whose fields are walked
+	nested    *structSchema
+	isPointer bool
+}
+
+type schemaKey struct {
+	location   string
+	tagName    string
+	structType reflect.Type
+
+	// Whether the fields of nested structures are identified by their dotted path (`address.city`)
+	dotted bool
+
+	// The prefix of all of the identifiers of the structure (`v2_`)
+	prefix string
+
+	// Whether the `json` tag identifies the fields that don't have the binder tag
+	jsonFallback bool
+}
+
+type cachedSchema struct {
+	schema *structSchema
+	err    error
+}
+
+var (
+	schemaCacheLock sync.RWMutex
+	schemaCache     = map[schemaKey]cachedSchema{}
+)
+
+// Returns the schema of the structure type of the key, building it on the first call
+func getStructSchema(key schemaKey) (*structSchema, error) {
+	location := key.location
+
+	schemaCacheLock.RLock()
+	cached, ok := schemaCache[key]
+	schemaCacheLock.RUnlock()
+
+	if !ok {
+		cached.schema, cached.err = buildStructSchema(key, key.structType, key.prefix, map[reflect.Type]bool{})
+		if cached.err == nil {
+			cached.err = cached.schema.checkIdentifiers(location, map[string]bool{})
+		}
+
+		schemaCacheLock.Lock()
+		schemaCache[key] = cached
+		schemaCacheLock.Unlock()
+	}
+
+	return cached.schema, cached.err
+}
+
+// Builds the schema of the structure type as described by key, visiting holds the struct types that are currently
+// being walked so self-referencing structures won't be expanded forever. The identifiers are prefixed with prefix,
+// which is the dotted path to the structure when the key is dotted.
+func buildStructSchema(key schemaKey, structType reflect.Type, prefix string, visiting map[reflect.Type]bool) (*structSchema, error) {
+	location := key.location
+	schema := &structSchema{location: location, skipped: map[string]bool{}}
+
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		fieldType := structType.Field(i)
+
+		// Unexported fields can't be set, so there is no point in binding them (embedded ones may still promote exported fields)
+		if !fieldType.IsExported() && !fieldType.Anonymous {
+			continue
+		}
+
+		// If the field is an anonymous field, we need to get the fields of the struct it points to
+		if fieldType.Anonymous {
+			kind := fieldType.Type.Kind()
+
+			// If the kind is a pointer let's get the real kind
+			if kind == reflect.Ptr {
+				kind = fieldType.Type.Elem().Kind()
+			}
+
+			// Embedded interfaces are set by the caller (for example to a shared implementation), so they are left alone
+			if kind == reflect.Interface {
+				continue
+			}
+
+			// If its not a struct, we can't get the fields of it
+			if kind != reflect.Struct {
+				return nil, getInvalidAnonymousFieldError(location)
+			}
+		}
+
+		kind := fieldType.Type.Kind()
+		nestedType := fieldType.Type
+		isPointer := false
+
+		// If the kind is a pointer let's get the real kind
+		if kind == reflect.Ptr {
+			nestedType = fieldType.Type.Elem()
+			kind = nestedType.Kind()
+			isPointer = true
+		}
+
+		identifier, options := parseTag(fieldType.Tag.Get(key.tagName))
+		if identifier == "-" {
+			// The field is skipped in all of the sections, nested structures included
+			schema.skipped[prefix+fieldType.Name] = true
+			continue
+		}
+
+		if identifier == "" && key.jsonFallback {
+			if name, _ := parseTag(fieldType.Tag.Get(jsonTag)); name != "-" {
+				identifier = name
+			}
+		}
+
+		// If the kind is a struct, let's get the fields of it (unless the struct is bound as a whole).
+		if kind == reflect.Struct && (fieldType.Anonymous || !isLeafType(fieldType.Type, options)) {
+			if isPointer && visiting[nestedType] {
+				// Self-referencing structure, expanding it would never end
+				continue
+			}
+
+			nestedPrefix := prefix
+			if key.dotted && !fieldType.Anonymous {
+				// Embedded structures are promoted, while the fields of other ones are nested under their name
+				if identifier == "" {
+					identifier = fieldType.Name
+				}
+
+				nestedPrefix += identifier + "."
+			}
+
+			nested, err := buildStructSchema(key, nestedType, nestedPrefix, visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			schema.entries = append(schema.entries, schemaEntry{index: i, nested: nested, isPointer: isPointer})
+			schema.size += nested.size
+			for name := range nested.skipped {
+				schema.skipped[name] = true
+			}
+			continue
+		}
+
+		if identifier == "" {
+			identifier = fieldType.Name
+
+			// Untagged header fields are matched by their name in the form of a header (`ContentType` is `Content-Type`)
+			if location == headerField {
+				identifier = getHeaderFieldName(fieldType.Name)
+			}
+		}
+
+		if kind, ok := getUnsupportedKind(fieldType.Type); ok {
+			return nil, getUnsupportedKindAtLocationError(location, fieldType.Name, kind)
+		}
+
+		schema.entries = append(schema.entries, schemaEntry{
+			index:      i,
+			identifier: prefix + identifier,
+			name:       fieldType.Name,
+			tag:        fieldType.Tag,
+			options:    options,
+		})
+		schema.size++
+	}
+
+	return schema, nil
+}
+
+// Makes sure that every identifier in the schema (including the nested structures) is bound into a single field,
+// since otherwise one of the fields would silently win over the others.
+func (schema *structSchema) checkIdentifiers(location string, seen map[string]bool) error {
+	for i := range schema.entries {
+		entry := &schema.entries[i]
+
+		if entry.nested != nil {
+			if err := entry.nested.checkIdentifiers(location, seen); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Headers are case insensitive, so their identifiers collide by their canonical form
+		identifier := entry.identifier
+		if location == headerField {
+			identifier = textproto.CanonicalMIMEHeaderKey(identifier)
+		}
+
+		if seen[identifier] {
+			return getDuplicateIdentifierError(location, identifier)
+		}
+
+		seen[identifier] = true
+	}
+
+	return nil
+}
+
+// Calls visit with the type of every field that is bound as a single value, structType is the type the schema was
+// built for
+func (schema *structSchema) walk(structType reflect.Type, visit func(reflect.Type) error) error {
+	for i := range schema.entries {
+		entry := &schema.entries[i]
+		fieldType := structType.Field(entry.index).Type
+
+		if entry.nested == nil {
+			if err := visit(fieldType); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if entry.isPointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if err := entry.nested.walk(fieldType, visit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Resolves the fields of the schema inside structField into fields, keyed by their identifiers (which are unique, as
+// checkIdentifiers rejects the schemas that bind one identifier into several fields). The field data is appended to
+// data, which must have the capacity for all of the schema fields so the pointers to it stay valid, allocate links the
+// nil pointers leading to structField and allocated reports whether they were linked.
+func (schema *structSchema) resolve(structField reflect.Value, allocate func(), allocated func() bool, fields map[string]*structFieldData, data *[]structFieldData) {
+	for i := range schema.entries {
+		entry := &schema.entries[i]
+		fieldStruct := structField.Field(entry.index)
+
+		if entry.nested == nil {
+			*data = append(*data, structFieldData{
+				FieldName:  entry.name,
+				Tag:        entry.tag,
+				Options:    entry.options,
+				location:   schema.location,
+				identifier: entry.identifier,
+				allocate:   allocate,
+				allocated:  allocated,
+				value:      fieldStruct,
+			})
+
+			field := &(*data)[len(*data)-1]
+			field.Value = &field.value
+			fields[entry.identifier] = field
+			continue
+		}
+
+		fieldAllocate, fieldAllocated := allocate, allocated
+
+		if entry.isPointer {
+			if fieldStruct.IsNil() {
+				if !fieldStruct.CanSet() {
+					// Can't allocate an unexported pointer, so there is nothing to bind into
+					continue
+				}
+
+				// Bind into a detached structure, and only link it once one of its fields is actually set
+				pointer, target := fieldStruct, reflect.New(fieldStruct.Type().Elem())
+				fieldAllocate = func() {
+					if pointer.IsNil() {
+						pointer.Set(target)
+					}
+
+					if allocate != nil {
+						allocate()
+					}
+				}
+
+				// Linking the pointer links the ones leading to it as well
+				fieldAllocated = func() bool {
+					return !pointer.IsNil()
+				}
+
+				fieldStruct = target
+			}
+
+			fieldStruct = fieldStruct.Elem()
+		}
+
+		entry.nested.resolve(fieldStruct, fieldAllocate, fieldAllocated, fields, data)
+	}
+}
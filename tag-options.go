@@ -0,0 +1,66 @@
+package echo_binder
+
+import (
+	"strings"
+	"sync"
+)
+
+// The options that follow the identifier in the binder tag, for example `binder:"payload,json"`.
+// Options can also hold a value, for example `binder:"tags,max=5"`.
+type tagOptions map[string]string
+
+type parsedTag struct {
+	identifier string
+	options    tagOptions
+}
+
+var (
+	parsedTagsLock sync.RWMutex
+	parsedTags     = map[string]parsedTag{}
+)
+
+// Splits the binder tag into the identifier and the options that follow it.
+// The tags are parsed once and cached, so the returned options must not be modified.
+func parseTag(tag string) (string, tagOptions) {
+	parsedTagsLock.RLock()
+	parsed, ok := parsedTags[tag]
+	parsedTagsLock.RUnlock()
+
+	if !ok {
+		parsed.identifier, parsed.options = splitTag(tag)
+
+		parsedTagsLock.Lock()
+		parsedTags[tag] = parsed
+		parsedTagsLock.Unlock()
+	}
+
+	return parsed.identifier, parsed.options
+}
+
+func splitTag(tag string) (string, tagOptions) {
+	parts := strings.Split(tag, ",")
+	options := tagOptions{}
+
+	for _, option := range parts[1:] {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		options[key] = value
+	}
+
+	return strings.TrimSpace(parts[0]), options
+}
+
+// Returns whether the option is declared in the tag
+func (options tagOptions) Has(name string) bool {
+	_, ok := options[name]
+	return ok
+}
+
+// Returns the value of the option, or an empty string if it's absent or has no value
+func (options tagOptions) Get(name string) string {
+	return options[name]
+}
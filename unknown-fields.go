@@ -0,0 +1,80 @@
+package echo_binder
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// Returns all of the sent fields (by their dotted paths, sorted) that don't exist in the struct type, sent is the
+// lookup table of the body. Fields of nested objects are only checked when the struct field is a struct as well.
+func getUnknownJSONFields(structType reflect.Type, sent RecursiveLookupTable, prefix string) []string {
+	unknown := []string{}
+
+	for key, nested := range sent {
+		fieldType, ok := getJSONFieldType(structType, key)
+		if !ok {
+			unknown = append(unknown, prefix+key)
+			continue
+		}
+
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && len(nested) > 0 && !reflect.PtrTo(fieldType).Implements(jsonUnmarshalerType) {
+			unknown = append(unknown, getUnknownJSONFields(fieldType, nested, prefix+key+".")...)
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Returns the type of the struct field that encoding/json decodes the key into, either by its json tag or
+// (case insensitively) by its name, including the fields that are promoted from embedded structs
+func getJSONFieldType(structType reflect.Type, key string) (reflect.Type, bool) {
+	var folded reflect.Type
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _ := parseTag(field.Tag.Get(jsonTag))
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+
+			if embeddedType.Kind() == reflect.Struct {
+				if fieldType, ok := getJSONFieldType(embeddedType, key); ok {
+					return fieldType, true
+				}
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if name == key {
+			return field.Type, true
+		} else if folded == nil && strings.EqualFold(name, key) {
+			folded = field.Type
+		}
+	}
+
+	return folded, folded != nil
+}
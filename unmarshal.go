@@ -1,22 +1,60 @@
 package echo_binder
 
 import (
+	"database/sql"
 	"encoding"
 	"errors"
+	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 // This file is taken from the echo framework
 
+var (
+	bindUnmarshalerType = reflect.TypeOf((*echo.BindUnmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	regexpType          = reflect.TypeOf(regexp.Regexp{})
+)
+
+// Returns whether the type (or a pointer to it) can unmarshal itself from a string value
+func isUnmarshalerType(fieldType reflect.Type) bool {
+	pointerType := reflect.PtrTo(fieldType)
+	return pointerType.Implements(bindUnmarshalerType) || pointerType.Implements(textUnmarshalerType) || pointerType.Implements(scannerType)
+}
+
+// Returns whether the type (or a pointer to it) is a sql.Scanner, such as sql.NullString
+func isScannerType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	return reflect.PtrTo(fieldType).Implements(scannerType)
+}
+
 func setWithProperType(valueKind reflect.Kind, val string, structField *reflect.Value) error {
+	// Patterns are compiled into regexp.Regexp (or *regexp.Regexp) fields (newer versions of Go implement
+	// encoding.TextUnmarshaler for them, but without the length limit)
+	if structField.Type() == regexpType || structField.Type() == reflect.PtrTo(regexpType) {
+		return setRegexpField(val, structField)
+	}
+
 	// But also call it here, in case we're dealing with an array of BindUnmarshalers
 	if ok, err := unmarshalField(valueKind, val, structField); ok {
 		return err
 	}
 
+	// time.Duration is an int64, but its values are sent like `30s` or `1500ms`
+	if structField.Type() == durationType {
+		return setDurationField(val, structField)
+	}
+
 	switch valueKind {
 	case reflect.Ptr:
 		elem := structField.Elem()
@@ -49,6 +87,18 @@ func setWithProperType(valueKind reflect.Kind, val string, structField *reflect.
 		return setFloatField(val, 64, structField)
 	case reflect.String:
 		structField.SetString(val)
+	case reflect.Interface:
+		// Interfaces that hold a value which can unmarshal itself are unmarshaled into it
+		if ok, err := unmarshalInterfaceField(val, structField); ok {
+			return err
+		}
+
+		// Only empty interfaces can hold the raw string
+		if structField.NumMethod() != 0 {
+			return errors.New("unknown type")
+		}
+
+		structField.Set(reflect.ValueOf(val))
 	default:
 		return errors.New("unknown type")
 	}
@@ -73,6 +123,10 @@ func unmarshalFieldNonPtr(value string, field *reflect.Value) (bool, error) {
 	if unmarshaler, ok := fieldIValue.(encoding.TextUnmarshaler); ok {
 		return true, unmarshaler.UnmarshalText([]byte(value))
 	}
+	// Types of the database (such as sql.NullString) that don't unmarshal text scan the string instead
+	if scanner, ok := fieldIValue.(sql.Scanner); ok {
+		return true, scanner.Scan(value)
+	}
 
 	return false, nil
 }
@@ -87,6 +141,50 @@ func unmarshalFieldPtr(value string, field *reflect.Value) (bool, error) {
 	return unmarshalFieldNonPtr(value, &elem)
 }
 
+// Returns whether the interface field holds a value (or a pointer to one) that can unmarshal itself
+func holdsUnmarshaler(field *reflect.Value) bool {
+	if field.IsNil() {
+		return false
+	}
+
+	heldType := field.Elem().Type()
+	if heldType.Kind() == reflect.Ptr {
+		heldType = heldType.Elem()
+	}
+
+	return isUnmarshalerType(heldType)
+}
+
+// Unmarshals the value into the value that the interface field holds, so the caller picks the concrete type by
+// setting the field before the binding. Pointers (nil ones are allocated) are unmarshaled in place, while other
+// values are copied, unmarshaled and set back into the field.
+func unmarshalInterfaceField(value string, field *reflect.Value) (bool, error) {
+	if !holdsUnmarshaler(field) {
+		return false, nil
+	}
+
+	held := field.Elem()
+	if held.Kind() == reflect.Ptr {
+		if held.IsNil() {
+			held = reflect.New(held.Type().Elem())
+			field.Set(held)
+		}
+
+		elem := held.Elem()
+		return unmarshalFieldNonPtr(value, &elem)
+	}
+
+	copied := reflect.New(held.Type()).Elem()
+	copied.Set(held)
+
+	ok, err := unmarshalFieldNonPtr(value, &copied)
+	if ok && err == nil {
+		field.Set(copied)
+	}
+
+	return ok, err
+}
+
 func setIntField(value string, bitSize int, field *reflect.Value) error {
 	if value == "" {
 		value = "0"
@@ -138,3 +236,79 @@ func setFloatField(value string, bitSize int, field *reflect.Value) error {
 
 	return err
 }
+
+// Sets the value into an empty interface field as the scalar type it looks like: int64, float64, bool (only for
+// `true` and `false`) and finally string
+func setInferredScalarField(value string, field *reflect.Value) error {
+	if field.NumMethod() != 0 {
+		return errors.New("unknown type")
+	}
+
+	var scalar interface{} = value
+	if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
+		scalar = intVal
+	} else if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+		scalar = floatVal
+	} else if value == "true" || value == "false" {
+		scalar = value == "true"
+	}
+
+	field.Set(reflect.ValueOf(scalar))
+	return nil
+}
+
+func setDurationField(value string, field *reflect.Value) error {
+	if value == "" {
+		value = "0s"
+	}
+
+	durationVal, err := time.ParseDuration(value)
+	if err == nil {
+		field.SetInt(int64(durationVal))
+	}
+
+	return err
+}
+
+func setRegexpField(value string, field *reflect.Value) error {
+	// The regexp package runs in linear time, but compiling huge patterns is still expensive
+	if len(value) > maxRegexpLength {
+		return fmt.Errorf("regular expression is longer than %d characters", maxRegexpLength)
+	}
+
+	regexpVal, err := regexp.Compile(value)
+	if err != nil {
+		return err
+	}
+
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.ValueOf(regexpVal))
+	} else {
+		field.Set(reflect.ValueOf(regexpVal).Elem())
+	}
+
+	return nil
+}
+
+func setTimeField(value string, layout string, field *reflect.Value) error {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		elem := field.Elem()
+		field = &elem
+	}
+
+	if value == "" {
+		field.Set(reflect.ValueOf(time.Time{}))
+		return nil
+	}
+
+	timeVal, err := time.Parse(layout, value)
+	if err == nil {
+		field.Set(reflect.ValueOf(timeVal))
+	}
+
+	return err
+}